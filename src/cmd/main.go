@@ -1,16 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/exaring/otelpgx"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/multitracer"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
 	"src/internal/api/handler"
 	"src/internal/db"
+	"src/internal/logger"
+	"src/internal/mail"
+	"src/internal/metrics"
+	"src/internal/migrator"
 	"src/internal/repository"
 	"src/internal/service"
+	"src/internal/tracing"
+	"src/internal/worker"
+	"src/schema"
 	"src/server"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -19,8 +38,19 @@ import (
 // @title        API документация
 // @version      1.0
 // @description  Это API для управления пользователями, товарами и поставщиками.
+// @description  Язык сообщений выбирается по заголовку Accept-Language (ru, en); по умолчанию используется язык из настройки locale.default.
 // @host         localhost:5000
 // @BasePath     /api/v1
+//
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Токен доступа в формате "Bearer <JWT>": сотрудник получает его методом /auth/login, клиент — методом /client/login
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 API-ключ поставщика для маршрутов /integration. Ключ выпускает менеджер каталога методом /supplier/{id}/apiKeys
 func main() {
 	gin.SetMode(gin.ReleaseMode)
 
@@ -28,8 +58,17 @@ func main() {
 		log.Fatalf("error initializing configs: %s", err.Error())
 	}
 
+	appLogger, err := logger.New(os.Stdout, logger.Config{
+		Level:  viper.GetString("log.level"),
+		Format: viper.GetString("log.format"),
+	})
+	if err != nil {
+		log.Fatalf("error initializing logger: %s", err.Error())
+	}
+	slog.SetDefault(appLogger)
+
 	if err := godotenv.Load(); err != nil {
-		log.Fatalf("error loading env file: %s", err.Error())
+		fatal("error loading env file", err)
 	}
 
 	postgresDb, err := db.NewPostgresDB(db.Config{
@@ -39,19 +78,223 @@ func main() {
 		Password: os.Getenv("PostgresPassword"),
 		DBName:   viper.GetString("db.dbname"),
 		SSLMode:  viper.GetString("db.sslmode"),
+
+		MaxConns:          viper.GetInt32("db.pool.max_conns"),
+		MinConns:          viper.GetInt32("db.pool.min_conns"),
+		MaxConnLifetime:   viper.GetDuration("db.pool.max_conn_lifetime"),
+		MaxConnIdleTime:   viper.GetDuration("db.pool.max_conn_idle_time"),
+		HealthCheckPeriod: viper.GetDuration("db.pool.health_check_period"),
+
+		Tracer: multitracer.New(metrics.QueryTracer{}, logger.QueryTracer{}, otelpgx.NewTracer(otelpgx.WithTrimSQLInSpanName())),
 	})
 	if err != nil {
-		log.Fatalf("failed initializing db: %s", err.Error())
+		fatal("failed initializing db", err)
+	}
+	defer postgresDb.Close()
+
+	migrations, err := migrator.New(postgresDb, schema.Migrations)
+	if err != nil {
+		fatal("failed loading migrations", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(migrations, os.Args[2:]); err != nil {
+			fatal("migrate failed", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "staff" {
+		if err := runStaff(newServices(postgresDb, migrations, mail.NewLogSender(os.Stdout, "")), os.Args[2:]); err != nil {
+			fatal("staff command failed", err)
+		}
+		return
+	}
+
+	if viper.GetBool("db.auto_migrate") {
+		if err := migrations.Up(context.Background()); err != nil {
+			fatal("failed applying migrations", err)
+		}
 	}
 
+	if err := runServer(postgresDb, migrations); err != nil {
+		fatal("server stopped with error", err)
+	}
+}
+
+// fatal записывает ошибку в журнал и завершает процесс.
+func fatal(msg string, err error) {
+	slog.Error(msg, logger.Err(err))
+	os.Exit(1)
+}
+
+func newServices(postgresDb *pgxpool.Pool, migrations *migrator.Migrator, mailer mail.Sender) *service.Service {
 	repos := repository.NewRepositore(postgresDb)
-	services := service.NewService(repos)
-	handlers := handler.NewHandler(services)
+	return service.NewService(repos, migrations, mailer, service.Config{
+		ReservationDefaultTTL: viper.GetDuration("reservations.default_ttl"),
+		ReservationMaxTTL:     viper.GetDuration("reservations.max_ttl"),
+		JWTSecret:             []byte(os.Getenv("JWTSecret")),
+		JWTIssuer:             viper.GetString("auth.issuer"),
+		AccessTokenTTL:        viper.GetDuration("auth.access_ttl"),
+		RefreshTokenTTL:       viper.GetDuration("auth.refresh_ttl"),
+		ClientVerifyTTL:       viper.GetDuration("clients.verify_email_ttl"),
+		ClientResetTTL:        viper.GetDuration("clients.reset_password_ttl"),
+		ClientLinks: service.ClientLinks{
+			VerifyEmailURL:   viper.GetString("clients.verify_email_url"),
+			ResetPasswordURL: viper.GetString("clients.reset_password_url"),
+		},
+		HealthCheckTimeout:  viper.GetDuration("health.check_timeout"),
+		SoftDeleteRetention: viper.GetDuration("soft_delete.retention"),
+	})
+}
+
+// newMailer создаёт отправитель писем по настройкам mail. Сейчас поддерживается только
+// драйвер log: письма пишутся в файл mail.file или, если он не задан, в стандартный вывод.
+// Возвращаемый файл нужно закрыть при остановке; для стандартного вывода он равен nil.
+func newMailer() (mail.Sender, *os.File, error) {
+	driver := viper.GetString("mail.driver")
+	if driver != "log" {
+		return nil, nil, fmt.Errorf("unknown mail driver: %q", driver)
+	}
+
+	path := viper.GetString("mail.file")
+	if path == "" {
+		return mail.NewLogSender(os.Stdout, viper.GetString("mail.from")), nil, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mail.NewLogSender(file, viper.GetString("mail.from")), file, nil
+}
+
+// minJWTSecretLen — минимальная длина ключа подписи JWT: ключ HMAC-SHA256 короче
+// размера хеша легче подобрать.
+const minJWTSecretLen = 32
+
+// jwtSecretPlaceholder — значение-заглушка из ранних версий .env. Оно опубликовано
+// в репозитории, поэтому токены, подписанные им, может подделать кто угодно.
+const jwtSecretPlaceholder = "change-me-in-production"
+
+// checkJWTSecret отклоняет пустой, заглушечный и слишком короткий ключ подписи JWT.
+func checkJWTSecret(secret string) error {
+	switch {
+	case secret == "":
+		return errors.New("JWTSecret is not set")
+	case strings.HasPrefix(secret, jwtSecretPlaceholder):
+		return errors.New("JWTSecret is the placeholder value, generate a random secret")
+	case len(secret) < minJWTSecretLen:
+		return fmt.Errorf("JWTSecret must be at least %d bytes long", minJWTSecretLen)
+	}
+	return nil
+}
+
+func runServer(postgresDb *pgxpool.Pool, migrations *migrator.Migrator) error {
+	if err := checkJWTSecret(os.Getenv("JWTSecret")); err != nil {
+		return fmt.Errorf("error initializing auth: %w", err)
+	}
+
+	mailer, mailFile, err := newMailer()
+	if err != nil {
+		return fmt.Errorf("error initializing mail: %w", err)
+	}
+	if mailFile != nil {
+		defer mailFile.Close()
+	}
+
+	services := newServices(postgresDb, migrations, mailer)
+	metrics.RegisterPool(services.GetPoolStats)
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:    viper.GetString("tracing.exporter"),
+		ServiceName: viper.GetString("tracing.service_name"),
+		Endpoint:    viper.GetString("tracing.otlp_endpoint"),
+		Insecure:    viper.GetBool("tracing.otlp_insecure"),
+		SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		Output:      os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("error initializing tracing: %w", err)
+	}
+
+	handlers := handler.NewHandler(services, handler.Config{
+		DefaultLanguage: viper.GetString("locale.default"),
+		ServiceName:     viper.GetString("tracing.service_name"),
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var workers sync.WaitGroup
+	sweeper := worker.NewReservationSweeper(services.Reservation, viper.GetDuration("reservations.sweep_interval"))
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		sweeper.Run(workersCtx)
+	}()
+
+	purger := worker.NewDeletedPurger(services.Purge, viper.GetDuration("soft_delete.purge_interval"))
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		purger.Run(workersCtx)
+	}()
 
 	srv := new(server.Server)
-	if err := srv.Run(viper.GetString("port"), handlers.InitRoutes()); err != nil {
-		log.Fatalf("error occured while running server: %s", err.Error())
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.Run(viper.GetString("port"), handlers.InitRoutes())
+	}()
+	slog.Info("server started", "port", viper.GetString("port"))
+
+	select {
+	case err = <-serverErr:
+		if err != nil {
+			err = fmt.Errorf("error occured while running server: %w", err)
+		}
+	case <-ctx.Done():
+		// Повторный сигнал завершает процесс сразу, не дожидаясь остановки.
+		stop()
+		slog.Info("shutting down")
+		err = shutdown(srv, handlers)
+	}
+
+	stopWorkers()
+	workers.Wait()
+	slog.Info("background workers stopped")
+
+	postgresDb.Close()
+	slog.Info("database connections closed")
+
+	// Span'ы, накопленные за время остановки, отправляются последними.
+	tracingCtx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("shutdown.timeout"))
+	defer cancel()
+	if tracingErr := shutdownTracing(tracingCtx); tracingErr != nil {
+		slog.Error("error flushing traces", logger.Err(tracingErr))
+	}
+
+	return err
+}
+
+// shutdown снимает сервер с балансировки и ждёт завершения начатых запросов.
+// Настройка shutdown.drain_delay задаёт паузу между переключением /readyz и закрытием
+// соединений, shutdown.timeout — сколько ждать завершения запросов.
+func shutdown(srv *server.Server, handlers *handler.Handler) error {
+	handlers.SetReady(false)
+	time.Sleep(viper.GetDuration("shutdown.drain_delay"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("shutdown.timeout"))
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("error occured while shutting down server: %w", err)
 	}
+	slog.Info("in-flight requests drained")
+	return nil
 }
 
 func initConfig() error {
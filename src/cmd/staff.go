@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"src/internal/service"
+	"strings"
+)
+
+const staffUsage = "usage: staff create LOGIN ROLE (пароль читается из STAFF_PASSWORD или из stdin)"
+
+// runStaff создаёт учётную запись сотрудника из командной строки. Нужна, чтобы завести
+// первого администратора, который затем создаёт остальных через API.
+func runStaff(services *service.Service, args []string) error {
+	if len(args) != 3 || args[0] != "create" {
+		return errors.New(staffUsage)
+	}
+
+	password := os.Getenv("STAFF_PASSWORD")
+	if password == "" {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("ошибка при чтении пароля: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	id, err := services.CreateStaffUser(context.Background(), args[1], password, args[2])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(id.String())
+	return nil
+}
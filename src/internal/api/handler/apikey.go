@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+)
+
+// @Summary      Выпустить API-ключ поставщика
+// @Description  Создаёт ключ для интеграции поставщика с указанными правами и сроком действия. Открытый ключ возвращается только в этом ответе
+// @Tags         apiKeys
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id   path  string                 true  "UUID поставщика"
+// @Param        key  body  response.CreateAPIKey  true  "Название, права и срок действия ключа"
+// @Success      201  {object}  response.IssuedAPIKeyResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Поставщик не найден"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /supplier/{id}/apiKeys [post]
+func (h *Handler) createAPIKey(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return
+	}
+
+	var keyReq response.CreateAPIKey
+
+	if err := c.ShouldBindJSON(&keyReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	key, plainText, err := h.services.CreateAPIKey(c, supplierID, keyReq.Name, keyReq.Scopes, keyReq.ExpiresAt)
+	if err != nil {
+		newErrorResponse(c, err, "api_key.create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToIssuedAPIKeyResponse(key, plainText))
+}
+
+// @Summary      API-ключи поставщика
+// @Description  Возвращает страницу ключей поставщика, включая отозванные и истёкшие, начиная с последних выпущенных,
+// @Description  и курсор следующей страницы. Открытые ключи не возвращаются
+// @Tags         apiKeys
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id      path   string  true   "UUID поставщика"
+// @Param        limit   query  int     false  "Количество ключей на странице, от 1 до 100 (по умолчанию 20)"
+// @Param        cursor  query  string  false  "Курсор следующей страницы из next_cursor"
+// @Success      200  {object}  response.APIKeyListResponse  "Страница ключей поставщика"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID или параметров страницы"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /supplier/{id}/apiKeys [get]
+func (h *Handler) getAPIKeys(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	keys, next, err := h.services.GetSupplierAPIKeys(c, supplierID, page)
+	if err != nil {
+		newErrorResponse(c, err, "api_key.list_failed")
+		return
+	}
+
+	keyResponses := make([]response.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		keyResponses[i] = mapper.ToAPIKeyResponse(key)
+	}
+
+	c.JSON(http.StatusOK, response.APIKeyListResponse{
+		APIKeys: keyResponses,
+		Page:    pageResponse(next),
+	})
+}
+
+// @Summary      Ротация API-ключа
+// @Description  Отзывает ключ и выпускает новый с теми же названием и правами. Новый ключ действует до expires_at
+// @Description  из тела запроса, а без него — до того же срока, что и старый. Истёкший ключ не ротируется.
+// @Description  Открытый ключ возвращается только в этом ответе
+// @Tags         apiKeys
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id     path  string                 true   "UUID поставщика"
+// @Param        keyId  path  string                 true   "UUID ключа"
+// @Param        key    body  response.RotateAPIKey  false  "Срок действия нового ключа"
+// @Success      201  {object}  response.IssuedAPIKeyResponse
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID или срок действия в прошлом"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ключ не найден"
+// @Failure      409  {object}  response.Problem  "Ключ уже отозван или истёк"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /supplier/{id}/apiKeys/{keyId}/rotate [post]
+func (h *Handler) rotateAPIKey(c *gin.Context) {
+	supplierID, keyID, ok := parseAPIKeyParams(c)
+	if !ok {
+		return
+	}
+
+	// Тело необязательно: без него новый ключ действует до срока старого.
+	var rotateReq response.RotateAPIKey
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&rotateReq); err != nil {
+			newBindErrorResponse(c, err)
+			return
+		}
+	}
+
+	key, plainText, err := h.services.RotateAPIKey(c, supplierID, keyID, rotateReq.ExpiresAt)
+	if err != nil {
+		newErrorResponse(c, err, "api_key.rotate_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToIssuedAPIKeyResponse(key, plainText))
+}
+
+// @Summary      Отозвать API-ключ
+// @Description  Отзывает ключ поставщика. Запросы с этим ключом больше не принимаются
+// @Tags         apiKeys
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id     path  string  true  "UUID поставщика"
+// @Param        keyId  path  string  true  "UUID ключа"
+// @Success      200  {object}  map[string]string  "Ключ отозван"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ключ не найден"
+// @Failure      409  {object}  response.Problem  "Ключ уже отозван"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /supplier/{id}/apiKeys/{keyId} [delete]
+func (h *Handler) revokeAPIKey(c *gin.Context) {
+	supplierID, keyID, ok := parseAPIKeyParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.services.RevokeAPIKey(c, supplierID, keyID); err != nil {
+		newErrorResponse(c, err, "api_key.revoke_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "api_key.revoked_ok")})
+}
+
+func parseAPIKeyParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	keyID, err := uuid.Parse(c.Param("keyId"))
+	if err != nil {
+		newValidationErrorResponse(c, "keyId", "request.api_key_uuid")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return supplierID, keyID, true
+}
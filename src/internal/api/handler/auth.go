@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"slices"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+	"src/internal/repository/model"
+	"strings"
+)
+
+const principalCtx = "principal"
+
+// authenticate проверяет токен доступа из заголовка Authorization и сохраняет
+// сотрудника, от имени которого выполняется запрос, в контексте.
+func (h *Handler) authenticate(c *gin.Context) {
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		c.Header("WWW-Authenticate", `Bearer realm="api"`)
+		newProblemResponse(c, http.StatusUnauthorized, response.CodeUnauthorized, t(c, "auth.token_required"))
+		return
+	}
+
+	principal, err := h.services.ParseAccessToken(token)
+	if err != nil {
+		c.Header("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+		newErrorResponse(c, err, "auth.authentication_failed")
+		return
+	}
+
+	c.Set(principalCtx, principal)
+	c.Next()
+}
+
+// requireRoles пропускает запрос, только если роль сотрудника входит в roles.
+func requireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := currentPrincipal(c)
+		if !ok || !slices.Contains(roles, principal.Role) {
+			newProblemResponse(c, http.StatusForbidden, response.CodeForbidden, t(c, "auth.forbidden"))
+			return
+		}
+		c.Next()
+	}
+}
+
+func currentPrincipal(c *gin.Context) (model.Principal, bool) {
+	value, ok := c.Get(principalCtx)
+	if !ok {
+		return model.Principal{}, false
+	}
+	principal, ok := value.(model.Principal)
+	return principal, ok
+}
+
+// @Summary      Вход сотрудника
+// @Description  Проверяет логин и пароль и выдаёт токен доступа (JWT) и токен обновления
+// @Tags         auth
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        credentials  body  response.Login  true  "Логин и пароль"
+// @Success      200  {object}  response.TokenResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Неверный логин или пароль"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /auth/login [post]
+func (h *Handler) login(c *gin.Context) {
+	var loginReq response.Login
+
+	if err := c.ShouldBindJSON(&loginReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	tokens, err := h.services.Login(c, loginReq.Login, loginReq.Password)
+	if err != nil {
+		newErrorResponse(c, err, "auth.login_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToTokenResponse(tokens))
+}
+
+// @Summary      Обновление токенов
+// @Description  Обменивает токен обновления на новую пару токенов. Использованный токен обновления становится недействительным
+// @Tags         auth
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        token  body  response.RefreshToken  true  "Токен обновления"
+// @Success      200  {object}  response.TokenResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Токен обновления недействителен"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /auth/refresh [post]
+func (h *Handler) refresh(c *gin.Context) {
+	var refreshReq response.RefreshToken
+
+	if err := c.ShouldBindJSON(&refreshReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	tokens, err := h.services.Refresh(c, refreshReq.RefreshToken)
+	if err != nil {
+		newErrorResponse(c, err, "auth.refresh_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToTokenResponse(tokens))
+}
+
+// @Summary      Создание сотрудника
+// @Description  Создаёт учётную запись сотрудника с указанной ролью. Доступно только администратору
+// @Tags         auth
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        staff  body  response.CreateStaffUser  true  "Логин, пароль и роль"
+// @Success      201  {object}  map[string]string  "Сотрудник успешно создан"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      409  {object}  response.Problem  "Логин уже занят"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /staff/create [post]
+func (h *Handler) createStaffUser(c *gin.Context) {
+	var staffReq response.CreateStaffUser
+
+	if err := c.ShouldBindJSON(&staffReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	id, err := h.services.CreateStaffUser(c, staffReq.Login, staffReq.Password, staffReq.Role)
+	if err != nil {
+		newErrorResponse(c, err, "staff.create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": t(c, "staff.created"),
+		"id":      id.String(),
+	})
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+)
+
+// @Summary      Регистрация клиента
+// @Description  Создаёт клиента с адресом почты и паролем и отправляет письмо для подтверждения адреса почты
+// @Tags         clients
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        client  body  response.RegisterClient  true  "Адрес почты, пароль и данные клиента"
+// @Success      201  {object}  map[string]string  "Клиент зарегистрирован"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      409  {object}  response.Problem  "Адрес почты уже зарегистрирован"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /client/register [post]
+func (h *Handler) registerClient(c *gin.Context) {
+	var registerReq response.RegisterClient
+
+	if err := c.ShouldBindJSON(&registerReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	address := mapper.ToAddressModel(registerReq.Profile.Address)
+	user, err := mapper.ToUserModel(registerReq.Profile)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
+
+	id, err := h.services.RegisterClient(c, user, address, registerReq.Email, registerReq.Password)
+	if err != nil {
+		newErrorResponse(c, err, "client.register_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": t(c, "client.registered"),
+		"id":      id.String(),
+	})
+}
+
+// @Summary      Вход клиента
+// @Description  Проверяет адрес почты и пароль клиента и выдаёт токен доступа (JWT). Адрес почты должен быть подтверждён
+// @Tags         clients
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        credentials  body  response.ClientLogin  true  "Адрес почты и пароль"
+// @Success      200  {object}  response.TokenResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Неверный адрес почты или пароль"
+// @Failure      403  {object}  response.Problem  "Адрес почты не подтверждён"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /client/login [post]
+func (h *Handler) loginClient(c *gin.Context) {
+	var loginReq response.ClientLogin
+
+	if err := c.ShouldBindJSON(&loginReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	tokens, err := h.services.LoginClient(c, loginReq.Email, loginReq.Password)
+	if err != nil {
+		newErrorResponse(c, err, "auth.login_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToTokenResponse(tokens))
+}
+
+// @Summary      Подтверждение адреса почты
+// @Description  Подтверждает адрес почты клиента по токену из письма
+// @Tags         clients
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        token  body  response.ClientToken  true  "Токен из письма"
+// @Success      200  {object}  map[string]string  "Адрес почты подтверждён"
+// @Failure      400  {object}  response.Problem  "Токен недействителен или истёк"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /client/verifyEmail [post]
+func (h *Handler) verifyClientEmail(c *gin.Context) {
+	var tokenReq response.ClientToken
+
+	if err := c.ShouldBindJSON(&tokenReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	err := h.services.VerifyClientEmail(c, tokenReq.Token)
+	if err != nil {
+		newErrorResponse(c, err, "client.verify_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "client.email_verified")})
+}
+
+// @Summary      Запрос на смену пароля
+// @Description  Отправляет на адрес почты письмо со ссылкой для смены пароля. Ответ не зависит от того, зарегистрирован ли адрес
+// @Tags         clients
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        email  body  response.RequestPasswordReset  true  "Адрес почты"
+// @Success      202  {object}  map[string]string  "Письмо отправлено, если адрес зарегистрирован"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /client/requestPasswordReset [post]
+func (h *Handler) requestPasswordReset(c *gin.Context) {
+	var resetReq response.RequestPasswordReset
+
+	if err := c.ShouldBindJSON(&resetReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	err := h.services.RequestClientPasswordReset(c, resetReq.Email)
+	if err != nil {
+		newErrorResponse(c, err, "client.reset_request_failed")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": t(c, "client.reset_requested")})
+}
+
+// @Summary      Смена пароля
+// @Description  Устанавливает новый пароль клиента по токену из письма
+// @Tags         clients
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Param        reset  body  response.ResetPassword  true  "Токен из письма и новый пароль"
+// @Success      200  {object}  map[string]string  "Пароль изменён"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных или токен недействителен"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /client/resetPassword [post]
+func (h *Handler) resetPassword(c *gin.Context) {
+	var resetReq response.ResetPassword
+
+	if err := c.ShouldBindJSON(&resetReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	err := h.services.ResetClientPassword(c, resetReq.Token, resetReq.Password)
+	if err != nil {
+		newErrorResponse(c, err, "client.reset_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "client.password_changed")})
+}
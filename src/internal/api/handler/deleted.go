@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/repository/model"
+	"strconv"
+)
+
+// parseIncludeDeleted разбирает флаг include_deleted, добавляющий в список удалённые записи.
+// Удалённые записи видны только администраторам.
+func parseIncludeDeleted(c *gin.Context) (bool, bool) {
+	value := c.Query("include_deleted")
+	if value == "" {
+		return false, true
+	}
+
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		newValidationErrorResponse(c, "include_deleted", "request.bool_invalid", "include_deleted")
+		return false, false
+	}
+
+	if principal, _ := currentPrincipal(c); include && principal.Role != model.RoleAdmin {
+		newProblemResponse(c, http.StatusForbidden, response.CodeForbidden, t(c, "request.include_deleted_forbidden"))
+		return false, false
+	}
+
+	return include, true
+}
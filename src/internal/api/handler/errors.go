@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/domain"
+	"src/internal/i18n"
+	"src/internal/logger"
+	"src/internal/metrics"
+)
+
+// errorCode сопоставляет ошибку предметной области с HTTP-статусом и кодом ошибки.
+// Более конкретные виды проверяются раньше общих.
+func errorCode(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrInsufficientStock):
+		return http.StatusUnprocessableEntity, response.CodeInsufficientStock
+	case errors.Is(err, domain.ErrDuplicatePhone):
+		return http.StatusConflict, response.CodeDuplicatePhone
+	case errors.Is(err, domain.ErrVersionMismatch):
+		return http.StatusPreconditionFailed, response.CodePreconditionFailed
+	case errors.Is(err, domain.ErrUnauthorized):
+		return http.StatusUnauthorized, response.CodeUnauthorized
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden, response.CodeForbidden
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusBadRequest, response.CodeValidationFailed
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, response.CodeNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict, response.CodeConflict
+	default:
+		return http.StatusInternalServerError, response.CodeInternal
+	}
+}
+
+// newProblemResponse прерывает обработку запроса и отвечает телом application/problem+json.
+func newProblemResponse(c *gin.Context, status int, code, detail string, fieldErrors ...response.FieldError) {
+	c.Header("Content-Type", response.ProblemContentType)
+	c.AbortWithStatusJSON(status, response.Problem{
+		Type:     "/problems/" + code,
+		Title:    t(c, "problem."+code),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     code,
+		Errors:   fieldErrors,
+	})
+}
+
+// newErrorResponse отвечает клиенту ошибкой сервиса. key — ключ каталога сообщений,
+// описывающий неудавшуюся операцию. Подробности внутренних ошибок (текст SQL, драйвера)
+// пишутся в лог и клиенту не отдаются.
+func newErrorResponse(c *gin.Context, err error, key string) {
+	status, code := errorCode(err)
+	if code == response.CodeInsufficientStock {
+		metrics.InsufficientStockRejected(route(c))
+	}
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(c, i18n.Translate(i18n.Fallback, key),
+			"method", c.Request.Method, "route", route(c), logger.Err(err))
+		newProblemResponse(c, status, code, t(c, key))
+		return
+	}
+
+	lang := c.GetString(languageCtx)
+	var fieldErrors []response.FieldError
+	for _, fe := range domain.Fields(err) {
+		fieldErrors = append(fieldErrors, response.FieldError{
+			Field:   fe.Field,
+			Message: i18n.Translate(lang, fe.Key, fe.Args...),
+		})
+	}
+
+	newProblemResponse(c, status, code, t(c, key)+": "+domain.Localize(err, lang), fieldErrors...)
+}
+
+// newValidationErrorResponse отвечает ошибкой в одном поле запроса (параметре пути, query или тела).
+func newValidationErrorResponse(c *gin.Context, field, key string, args ...any) {
+	message := t(c, key, args...)
+	newProblemResponse(c, http.StatusBadRequest, response.CodeValidationFailed, message,
+		response.FieldError{Field: field, Message: message})
+}
+
+// newBindErrorResponse отвечает ошибкой разбора тела запроса. Если тело не прошло проверку
+// по тегам binding, в ответ попадают все нарушенные поля.
+func newBindErrorResponse(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fieldErrors := make([]response.FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			fieldErrors[i] = response.FieldError{Field: fieldPath(fe), Message: ruleMessage(c, fe)}
+		}
+		newProblemResponse(c, http.StatusBadRequest, response.CodeValidationFailed, t(c, "request.invalid_data"), fieldErrors...)
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		newValidationErrorResponse(c, typeErr.Field, "request.invalid_type", typeErr.Type.String())
+		return
+	}
+
+	newProblemResponse(c, http.StatusBadRequest, response.CodeMalformedBody, t(c, "request.malformed_body"))
+}
+
+func ruleMessage(c *gin.Context, fe validator.FieldError) string {
+	key, ok := ruleKeys[fe.Tag()]
+	if !ok {
+		return t(c, "request.rule_violated", fe.Tag())
+	}
+	if fe.Param() == "" {
+		return t(c, key)
+	}
+	return t(c, key, fe.Param())
+}
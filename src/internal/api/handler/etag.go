@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"src/internal/api/response"
+	"strconv"
+	"strings"
+)
+
+// setETag отдаёт версию объекта в заголовке ETag. Клиент возвращает её в If-Match
+// при изменении или удалении объекта.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch разбирает заголовок If-Match и возвращает ожидаемую версию объекта.
+// Заголовок обязателен: без него запрос отклоняется с 428, чтобы изменение не затёрло
+// чужие правки. Слабые ETag и «*» не принимаются — они не фиксируют версию.
+func parseIfMatch(c *gin.Context) (int, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		newProblemResponse(c, http.StatusPreconditionRequired, response.CodePreconditionRequired,
+			t(c, "request.if_match_required"))
+		return 0, false
+	}
+
+	tag, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		newValidationErrorResponse(c, "If-Match", "request.if_match_invalid")
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		newValidationErrorResponse(c, "If-Match", "request.if_match_invalid")
+		return 0, false
+	}
+
+	return version, true
+}
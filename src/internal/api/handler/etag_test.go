@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"src/internal/api/response"
+	"testing"
+)
+
+func TestSetETag(t *testing.T) {
+	c, recorder := newTestContext(http.MethodGet, "/", "")
+
+	setETag(c, 7)
+
+	if got := recorder.Header().Get("ETag"); got != `"7"` {
+		t.Fatalf("ETag = %s, want %q", got, `"7"`)
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      *string
+		wantVersion int
+		wantOK      bool
+		wantStatus  int
+		wantCode    string
+	}{
+		{name: "strong tag", header: ptr(`"3"`), wantVersion: 3, wantOK: true},
+		{name: "surrounding whitespace", header: ptr(`  "12"  `), wantVersion: 12, wantOK: true},
+		{name: "missing header", wantStatus: http.StatusPreconditionRequired, wantCode: response.CodePreconditionRequired},
+		{name: "blank header", header: ptr("   "), wantStatus: http.StatusPreconditionRequired, wantCode: response.CodePreconditionRequired},
+		{name: "weak tag", header: ptr(`W/"3"`), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "wildcard", header: ptr("*"), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "unquoted", header: ptr("3"), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "unterminated quote", header: ptr(`"3`), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "several tags", header: ptr(`"3", "4"`), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "not a number", header: ptr(`"abc"`), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "zero version", header: ptr(`"0"`), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+		{name: "negative version", header: ptr(`"-1"`), wantStatus: http.StatusBadRequest, wantCode: response.CodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newTestContext(http.MethodPatch, "/product/1", "")
+			if tt.header != nil {
+				c.Request.Header.Set("If-Match", *tt.header)
+			}
+
+			version, ok := parseIfMatch(c)
+
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok {
+				if version != tt.wantVersion {
+					t.Fatalf("version = %d, want %d", version, tt.wantVersion)
+				}
+				if c.IsAborted() {
+					t.Fatal("запрос прерван при корректном заголовке")
+				}
+				return
+			}
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if problem := decodeProblem(t, recorder); problem.Code != tt.wantCode {
+				t.Fatalf("code = %s, want %s", problem.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -2,80 +2,264 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/metrics"
+	"src/internal/repository/model"
 	"src/internal/service"
+	"sync/atomic"
 
 	_ "src/docs"
 )
 
+type Config struct {
+	DefaultLanguage string
+	// ServiceName — имя сервиса в span'ах HTTP-запросов.
+	ServiceName string
+}
+
 type Handler struct {
-	services *service.Service
+	services        *service.Service
+	defaultLanguage string
+	serviceName     string
+	ready           atomic.Bool
 }
 
-func NewHandler(services *service.Service) *Handler {
-	return &Handler{services: services}
+func NewHandler(services *service.Service, cfg Config) *Handler {
+	h := &Handler{
+		services:        services,
+		defaultLanguage: cfg.DefaultLanguage,
+		serviceName:     cfg.ServiceName,
+	}
+	h.ready.Store(true)
+	return h
 }
 
+// actor возвращает инициатора изменения для журнала движения товаров.
+func actor(c *gin.Context) string {
+	if principal, ok := currentPrincipal(c); ok {
+		return principal.Login
+	}
+	return "anonymous"
+}
+
+// Роли, которым разрешён вызов групп маршрутов.
+var (
+	anyStaff        = model.StaffRoles
+	adminOnly       = []string{model.RoleAdmin}
+	catalogManagers = []string{model.RoleAdmin, model.RoleCatalogManager}
+	warehouseStaff  = []string{model.RoleAdmin, model.RoleWarehouse}
+)
+
 func (h *Handler) InitRoutes() *gin.Engine {
+	registerValidators()
+
 	router := gin.New()
-	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
+	// Контекст запроса (с идентификатором для журнала) доступен через *gin.Context,
+	// который обработчики передают в сервисы.
+	router.ContextWithFallback = true
+	router.Use(otelgin.Middleware(h.serviceName, otelgin.WithFilter(traceable)))
+	router.Use(assignRequestID)
+	router.Use(observeRequests)
+	router.Use(logRequests)
+	router.Use(h.negotiateLanguage)
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		newProblemResponse(c, http.StatusInternalServerError, response.CodeInternal, t(c, "problem.internal_error"))
+	}))
+	router.NoRoute(func(c *gin.Context) {
+		newProblemResponse(c, http.StatusNotFound, response.CodeRouteNotFound, t(c, "problem.route_not_found"))
+	})
+
+	router.GET("/healthz", h.healthz)
+	router.GET("/readyz", h.readyz)
+	router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})))
 
 	router.StaticFile("/swagger.json", "./docs/openapi.json")
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/swagger.json")))
 
 	apiV1 := router.Group("/api/v1")
 	{
-		h.initUserRoutes(apiV1)
-		h.initSupplierRoutes(apiV1)
-		h.initProductRoutes(apiV1)
-		h.initImageRoutes(apiV1)
+		h.initAuthRoutes(apiV1)
+		h.initClientRoutes(apiV1)
+		h.initIntegrationRoutes(apiV1)
+
+		protected := apiV1.Group("", h.authenticate)
+		h.initMeRoutes(protected)
+		h.initStaffRoutes(protected)
+		h.initUserRoutes(protected)
+		h.initSupplierRoutes(protected)
+		h.initProductRoutes(protected)
+		h.initImageRoutes(protected)
+		h.initOrderRoutes(protected)
+		h.initReservationRoutes(protected)
+		h.initSystemRoutes(protected)
 	}
 
 	return router
 }
 
+func (h *Handler) initAuthRoutes(rg *gin.RouterGroup) {
+	auth := rg.Group("/auth")
+	{
+		auth.POST("/login", h.login)
+		auth.POST("/refresh", h.refresh)
+	}
+}
+
+func (h *Handler) initClientRoutes(rg *gin.RouterGroup) {
+	client := rg.Group("/client")
+	{
+		client.POST("/register", h.registerClient)
+		client.POST("/login", h.loginClient)
+		client.POST("/verifyEmail", h.verifyClientEmail)
+		client.POST("/requestPasswordReset", h.requestPasswordReset)
+		client.POST("/resetPassword", h.resetPassword)
+	}
+}
+
+func (h *Handler) initMeRoutes(rg *gin.RouterGroup) {
+	me := rg.Group("/me", requireRoles(model.RoleClient))
+	{
+		me.GET("", h.getMe)
+		me.PUT("", h.updateMe)
+		me.PUT("/address", h.updateMyAddress)
+	}
+}
+
+func (h *Handler) initStaffRoutes(rg *gin.RouterGroup) {
+	staff := rg.Group("/staff", requireRoles(adminOnly...))
+	{
+		staff.POST("/create", h.createStaffUser)
+	}
+}
+
 func (h *Handler) initUserRoutes(rg *gin.RouterGroup) {
 	user := rg.Group("/user")
 	{
-		user.POST("/create", h.createUser)
-		user.DELETE("/delete/:id", h.deleteUser)
-		user.GET("/users", h.getUsers)
-		user.GET("/usersList", h.getUserList)
-		user.PUT("/updateAddress/:id", h.updateUserAddress)
+		read := user.Group("", requireRoles(anyStaff...))
+		read.GET("/users", h.getUsers)
+		read.GET("/usersList", h.getUserList)
+		read.GET("/search", h.searchUsers)
+		read.GET("/:id", h.getUser)
+		read.GET("/:id/address", h.getUserAddress)
+
+		write := user.Group("", requireRoles(adminOnly...))
+		write.POST("/create", h.createUser)
+		write.DELETE("/delete/:id", h.deleteUser)
+		write.PUT("/updateAddress/:id", h.updateUserAddress)
+		write.PATCH("/:id", h.patchUser)
+		write.POST("/:id/restore", h.restoreUser)
 	}
 }
 
 func (h *Handler) initSupplierRoutes(rg *gin.RouterGroup) {
 	supplier := rg.Group("/supplier")
 	{
-		supplier.POST("/create", h.createSupplier)
-		supplier.PUT("/updateAddress/:id", h.updateSupplierAddress)
-		supplier.DELETE("/delete/:id", h.deleteSupplier)
-		supplier.GET("/supplierList", h.getSupplierList)
-		supplier.GET("/:id", h.getSupplier)
+		read := supplier.Group("", requireRoles(anyStaff...))
+		read.GET("/supplierList", h.getSupplierList)
+		read.GET("/:id", h.getSupplier)
+		read.GET("/:id/address", h.getSupplierAddress)
+
+		write := supplier.Group("", requireRoles(catalogManagers...))
+		write.POST("/create", h.createSupplier)
+		write.PUT("/updateAddress/:id", h.updateSupplierAddress)
+		write.PATCH("/:id", h.patchSupplier)
+		write.DELETE("/delete/:id", h.deleteSupplier)
+
+		admin := supplier.Group("", requireRoles(adminOnly...))
+		admin.POST("/:id/restore", h.restoreSupplier)
+
+		apiKeys := supplier.Group("/:id/apiKeys", requireRoles(catalogManagers...))
+		apiKeys.POST("", h.createAPIKey)
+		apiKeys.GET("", h.getAPIKeys)
+		apiKeys.POST("/:keyId/rotate", h.rotateAPIKey)
+		apiKeys.DELETE("/:keyId", h.revokeAPIKey)
+	}
+}
+
+// initIntegrationRoutes регистрирует маршруты для интеграций поставщиков.
+// Запросы аутентифицируются API-ключом, а не токеном сотрудника.
+func (h *Handler) initIntegrationRoutes(rg *gin.RouterGroup) {
+	integration := rg.Group("/integration", h.authenticateAPIKey)
+	{
+		integration.GET("/products", requireScope(model.ScopeProductsRead), h.getIntegrationProducts)
+		integration.POST("/product/:id/restock", requireScope(model.ScopeStockWrite), h.integrationRestock)
+		integration.POST("/product/:id/adjustStock", requireScope(model.ScopeStockWrite), h.integrationAdjustStock)
+		integration.PATCH("/product/:id/price", requireScope(model.ScopePricesWrite), h.integrationUpdatePrice)
 	}
 }
 
 func (h *Handler) initProductRoutes(rg *gin.RouterGroup) {
 	product := rg.Group("/product")
 	{
-		product.POST("/create", h.createProduct)
-		product.PATCH("/updateQuantity", h.reduceStock)
-		product.GET("/:id", h.getProduct)
-		product.GET("/productList", h.getProductList)
-		product.DELETE("/delete/:id", h.deleteProduct)
+		read := product.Group("", requireRoles(anyStaff...))
+		read.GET("/:id/stockMovements", h.getStockMovements)
+		read.GET("/:id", h.getProduct)
+		read.GET("/productList", h.getProductList)
+		read.GET("/search", h.searchProducts)
+
+		catalog := product.Group("", requireRoles(catalogManagers...))
+		catalog.POST("/create", h.createProduct)
+		catalog.DELETE("/delete/:id", h.deleteProduct)
+		catalog.PATCH("/:id", h.patchProduct)
+
+		admin := product.Group("", requireRoles(adminOnly...))
+		admin.POST("/:id/restore", h.restoreProduct)
+
+		stock := product.Group("", requireRoles(warehouseStaff...))
+		stock.PATCH("/updateQuantity", h.reduceStock)
+		stock.POST("/:id/restock", h.restock)
+		stock.POST("/:id/adjustStock", h.adjustStock)
 	}
 }
 
 func (h *Handler) initImageRoutes(rg *gin.RouterGroup) {
 	image := rg.Group("/image")
 	{
-		image.POST("/create", h.createImage)
-		image.PUT("/updateImage", h.updateImage)
-		image.DELETE("/delete/:id", h.deleteImage)
-		image.GET("/product/:id", h.getImageByProductId)
-		image.GET("/:id", h.getImageById)
+		read := image.Group("", requireRoles(anyStaff...))
+		read.GET("/product/:id", h.getImageByProductId)
+		read.GET("/:id", h.getImageById)
+
+		write := image.Group("", requireRoles(catalogManagers...))
+		write.POST("/create", h.createImage)
+		write.PUT("/updateImage", h.updateImage)
+		write.DELETE("/delete/:id", h.deleteImage)
+	}
+}
+
+func (h *Handler) initOrderRoutes(rg *gin.RouterGroup) {
+	order := rg.Group("/order")
+	{
+		read := order.Group("", requireRoles(anyStaff...))
+		read.GET("/client/:id", h.getClientOrders)
+		read.GET("/:id", h.getOrder)
+
+		write := order.Group("", requireRoles(warehouseStaff...))
+		write.POST("/checkout", h.checkout)
+		write.PATCH("/:id/status", h.updateOrderStatus)
+	}
+}
+
+func (h *Handler) initReservationRoutes(rg *gin.RouterGroup) {
+	reservation := rg.Group("/reservation")
+	{
+		read := reservation.Group("", requireRoles(anyStaff...))
+		read.GET("/:id", h.getReservation)
+
+		write := reservation.Group("", requireRoles(warehouseStaff...))
+		write.POST("/create", h.createReservation)
+		write.POST("/:id/confirm", h.confirmReservation)
+		write.POST("/:id/release", h.releaseReservation)
+	}
+}
+
+func (h *Handler) initSystemRoutes(rg *gin.RouterGroup) {
+	system := rg.Group("/system", requireRoles(adminOnly...))
+	{
+		system.GET("/dbPool", h.getPoolStats)
 	}
 }
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http/httptest"
+	"src/internal/api/response"
+	"strings"
+	"testing"
+)
+
+// newTestContext возвращает контекст gin для запроса method target с телом body
+// и запись ответа.
+func newTestContext(method, target, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	c.Request = httptest.NewRequest(method, target, reader)
+
+	return c, recorder
+}
+
+// decodeProblem разбирает тело ответа с ошибкой.
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) response.Problem {
+	t.Helper()
+
+	var problem response.Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("тело ответа не является problem+json: %v: %s", err, recorder.Body.String())
+	}
+	return problem
+}
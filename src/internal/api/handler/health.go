@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/domain"
+	"src/internal/logger"
+	"src/internal/middleware/mapper"
+	"src/internal/repository/model"
+)
+
+// Пробы /healthz и /readyz, как и /metrics, предназначены для оркестратора и мониторинга:
+// они не входят в /api/v1, не требуют аутентификации и не описаны в Swagger.
+
+// SetReady переключает ответ /readyz. При остановке сервер сначала перестаёт быть готовым,
+// чтобы балансировщик убрал его из ротации, и только потом закрывает соединения.
+func (h *Handler) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// healthz — проба живости: отвечает, пока процесс обслуживает запросы, и не обращается к зависимостям.
+func (h *Handler) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, response.HealthResponse{Status: response.StatusOK})
+}
+
+// readyz — проба готовности. Отвечает 503, если сервер останавливается или хотя бы одна
+// зависимость недоступна, и возвращает результат проверки каждой зависимости.
+func (h *Handler) readyz(c *gin.Context) {
+	if !h.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, response.ReadinessResponse{Status: response.StatusDraining})
+		return
+	}
+
+	readiness := response.ReadinessResponse{
+		Status: response.StatusOK,
+		Checks: make(map[string]response.DependencyResponse),
+	}
+
+	for _, check := range h.services.CheckDependencies(c.Request.Context()) {
+		dependency := mapper.ToDependencyResponse(check)
+		if !check.Healthy {
+			readiness.Status = response.StatusUnavailable
+			dependency.Error = dependencyError(c, check)
+		}
+		readiness.Checks[check.Name] = dependency
+	}
+
+	status := http.StatusOK
+	if readiness.Status != response.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, readiness)
+}
+
+// dependencyError возвращает описание ошибки проверки на языке запроса.
+// Подробности ошибок драйвера пишутся в лог и в ответ не попадают.
+func dependencyError(c *gin.Context, check model.DependencyCheck) string {
+	if message := domain.Localize(check.Err, c.GetString(languageCtx)); message != "" {
+		return message
+	}
+
+	slog.WarnContext(c, "readiness check failed", "dependency", check.Name, logger.Err(check.Err))
+	return t(c, "health.check_failed")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"net/http"
 	"src/internal/api/response"
+	"src/internal/metrics"
 	"src/internal/middleware/mapper"
 )
 
@@ -13,35 +14,39 @@ import (
 // @Description  Создаёт новое изображение и привязывает его к продукту
 // @Tags         images
 // @Accept       json
-// @Produce      json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
 // @Param        request body response.UploadUpdateImage true "Данные изображения"
 // @Success      201 {object} map[string]string
-// @Failure      400 {object} map[string]string
-// @Failure      500 {object} map[string]string
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /image/create [post]
 func (h *Handler) createImage(c *gin.Context) {
 	var imageReq response.UploadUpdateImage
 
 	if err := c.ShouldBindJSON(&imageReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
 	image := mapper.ToImageModel(imageReq)
 	productID, err := uuid.Parse(imageReq.ID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID продукта"})
+		newValidationErrorResponse(c, "product_id", "request.product_uuid")
 		return
 	}
 
 	id, err := h.services.CreateImage(c, image, productID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Не удалось создать изображение: %s", err.Error())})
+		newErrorResponse(c, err, "image.create_failed")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Изображение успешно создано",
+		"message": t(c, "image.created"),
 		"id":      id.String(),
 	})
 }
@@ -50,82 +55,94 @@ func (h *Handler) createImage(c *gin.Context) {
 // @Description  Обновляет данные изображения по его ID
 // @Tags         images
 // @Accept       json
-// @Produce      json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
 // @Param        request body response.UploadUpdateImage true "Обновленные данные изображения"
 // @Success      200 {object} map[string]string
-// @Failure      400 {object} map[string]string
-// @Failure      404 {object} map[string]string
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /image/updateImage [put]
 func (h *Handler) updateImage(c *gin.Context) {
 	var imageReq response.UploadUpdateImage
 
 	if err := c.ShouldBindJSON(&imageReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
 	image := mapper.ToImageModel(imageReq)
 	imageID, err := uuid.Parse(imageReq.ID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID продукта"})
+		newValidationErrorResponse(c, "product_id", "request.image_uuid")
 		return
 	}
 
 	err = h.services.UpdateImage(c, image, imageID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Не удалось создать изображение: %s", err.Error())})
+		newErrorResponse(c, err, "image.update_failed")
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Изображение успешно изменино",
+	c.JSON(http.StatusOK, gin.H{
+		"message": t(c, "image.updated"),
 	})
 }
 
 // @Summary      Удалить изображение
 // @Description  Удаляет изображение по ID
 // @Tags         images
-// @Produce      json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
 // @Param        id path string true "UUID изображения"
 // @Success      200 {object} map[string]string
-// @Failure      400 {object} map[string]string
-// @Failure      404 {object} map[string]string
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /image/delete/{id} [delete]
 func (h *Handler) deleteImage(c *gin.Context) {
 	imageID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID изображения"})
+		newValidationErrorResponse(c, "id", "request.image_uuid")
 		return
 	}
 
 	err = h.services.DeleteImage(c, imageID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Не удалось удалить изображение: %s", err.Error())})
+		newErrorResponse(c, err, "image.delete_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Изображение успешно удалено"})
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "image.deleted")})
 }
 
 // @Summary      Получить изображение по ID продукта
 // @Description  Возвращает изображение по UUID продукта
 // @Tags         images
-// @Produce      octet-stream
+// @Produce      octet-stream,application/problem+json
+// @Security     BearerAuth
 // @Param        id path string true "UUID продукта"
 // @Success      200 {file} binary
-// @Failure      400 {object} map[string]string
-// @Failure      404 {object} map[string]string
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /image/product/{id} [get]
 func (h *Handler) getImageByProductId(c *gin.Context) {
 	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID продукта"})
+		newValidationErrorResponse(c, "id", "request.product_uuid")
 		return
 	}
 
 	image, err := h.services.GetImageByProductId(c, productID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении изображения: %s", err.Error())})
+		newErrorResponse(c, err, "image.get_failed")
 		return
 	}
 
@@ -133,27 +150,32 @@ func (h *Handler) getImageByProductId(c *gin.Context) {
 	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.png"`, image.ID))
 
 	c.Data(http.StatusOK, "application/octet-stream", image.Image)
+	metrics.ImageServed(len(image.Image))
 }
 
 // @Summary      Получить изображение по его ID
 // @Description  Возвращает изображение по UUID
 // @Tags         images
-// @Produce      octet-stream
+// @Produce      octet-stream,application/problem+json
+// @Security     BearerAuth
 // @Param        id path string true "UUID изображения"
 // @Success      200 {file} binary
-// @Failure      400 {object} map[string]string
-// @Failure      404 {object} map[string]string
+// @Failure      400 {object} response.Problem
+// @Failure      401 {object} response.Problem
+// @Failure      403 {object} response.Problem
+// @Failure      404 {object} response.Problem
+// @Failure      500 {object} response.Problem
 // @Router       /image/{id} [get]
 func (h *Handler) getImageById(c *gin.Context) {
 	imageID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID изображения"})
+		newValidationErrorResponse(c, "id", "request.image_uuid")
 		return
 	}
 
 	image, err := h.services.GetImageById(c, imageID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении изображения: %s", err.Error())})
+		newErrorResponse(c, err, "image.get_failed")
 		return
 	}
 
@@ -161,4 +183,5 @@ func (h *Handler) getImageById(c *gin.Context) {
 	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.png"`, imageID))
 
 	c.Data(http.StatusOK, "application/octet-stream", image.Image)
+	metrics.ImageServed(len(image.Image))
 }
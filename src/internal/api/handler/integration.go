@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"slices"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+	"src/internal/repository/model"
+)
+
+const apiKeyCtx = "apiKey"
+
+// authenticateAPIKey проверяет ключ поставщика из заголовка X-API-Key и сохраняет его в контексте.
+// Инициатором изменений в журнале движения товаров записывается префикс ключа.
+func (h *Handler) authenticateAPIKey(c *gin.Context) {
+	plainText := c.GetHeader("X-API-Key")
+	if plainText == "" {
+		newProblemResponse(c, http.StatusUnauthorized, response.CodeUnauthorized, t(c, "api_key.required"))
+		return
+	}
+
+	key, err := h.services.AuthenticateAPIKey(c, plainText)
+	if err != nil {
+		newErrorResponse(c, err, "auth.authentication_failed")
+		return
+	}
+
+	c.Set(apiKeyCtx, key)
+	c.Set(principalCtx, model.Principal{ID: key.SupplierID, Login: "api-key:" + key.Prefix})
+	c.Next()
+}
+
+// requireScope пропускает запрос, только если у ключа есть право scope.
+func requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := currentAPIKey(c)
+		if !ok || !slices.Contains(key.Scopes, scope) {
+			newProblemResponse(c, http.StatusForbidden, response.CodeForbidden, t(c, "api_key.scope_missing", scope))
+			return
+		}
+		c.Next()
+	}
+}
+
+func currentAPIKey(c *gin.Context) (model.APIKey, bool) {
+	value, ok := c.Get(apiKeyCtx)
+	if !ok {
+		return model.APIKey{}, false
+	}
+	key, ok := value.(model.APIKey)
+	return key, ok
+}
+
+// @Summary      Товары поставщика
+// @Description  Возвращает товары поставщика, которому выдан ключ. Требуется право products:read
+// @Tags         integration
+// @Produce      json,application/problem+json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  map[string][]response.ProductResponse  "Товары поставщика"
+// @Failure      401  {object}  response.Problem  "Ключ недействителен"
+// @Failure      403  {object}  response.Problem  "У ключа нет нужного права"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /integration/products [get]
+func (h *Handler) getIntegrationProducts(c *gin.Context) {
+	key, _ := currentAPIKey(c)
+
+	products, err := h.services.GetSupplierProducts(c, key.SupplierID)
+	if err != nil {
+		newErrorResponse(c, err, "product.list_failed")
+		return
+	}
+
+	productResponses := make([]response.ProductResponse, len(products))
+	for i, product := range products {
+		productResponses[i] = mapper.ToProductResponse(product)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"products": productResponses,
+	})
+}
+
+// @Summary      Поступление товара поставщика
+// @Description  Увеличивает остаток товара поставщика, которому выдан ключ. Требуется право stock:write
+// @Tags         integration
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     ApiKeyAuth
+// @Param        id       path  string            true  "UUID товара"
+// @Param        restock  body  response.Restock  true  "Количество поступившего товара"
+// @Success      200  {object}  response.StockResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Ключ недействителен"
+// @Failure      403  {object}  response.Problem  "У ключа нет нужного права"
+// @Failure      404  {object}  response.Problem  "Товар поставщика не найден"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /integration/product/{id}/restock [post]
+func (h *Handler) integrationRestock(c *gin.Context) {
+	key, _ := currentAPIKey(c)
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	var restockReq response.Restock
+
+	if err := c.ShouldBindJSON(&restockReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	stock, err := h.services.RestockSupplierProduct(c, key.SupplierID, productID, restockReq.Quantity, actor(c))
+	if err != nil {
+		newErrorResponse(c, err, "stock.change_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StockResponse{
+		ProductID:      productID.String(),
+		AvailableStock: stock,
+	})
+}
+
+// @Summary      Корректировка остатка товара поставщика
+// @Description  Изменяет остаток товара поставщика, которому выдан ключ, на указанную величину. Требуется право stock:write
+// @Tags         integration
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     ApiKeyAuth
+// @Param        id      path  string                true  "UUID товара"
+// @Param        adjust  body  response.AdjustStock  true  "Изменение остатка (может быть отрицательным)"
+// @Success      200  {object}  response.StockResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Ключ недействителен"
+// @Failure      403  {object}  response.Problem  "У ключа нет нужного права"
+// @Failure      404  {object}  response.Problem  "Товар поставщика не найден"
+// @Failure      422  {object}  response.Problem  "Недостаточно товара на складе"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /integration/product/{id}/adjustStock [post]
+func (h *Handler) integrationAdjustStock(c *gin.Context) {
+	key, _ := currentAPIKey(c)
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	var adjustReq response.AdjustStock
+
+	if err := c.ShouldBindJSON(&adjustReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	stock, err := h.services.AdjustSupplierProductStock(c, key.SupplierID, productID, adjustReq.Delta, actor(c))
+	if err != nil {
+		newErrorResponse(c, err, "stock.change_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StockResponse{
+		ProductID:      productID.String(),
+		AvailableStock: stock,
+	})
+}
+
+// @Summary      Изменить цену товара поставщика
+// @Description  Устанавливает новую цену товара поставщика, которому выдан ключ, если товар не менялся
+// @Description  с версии из If-Match. Требуется право prices:write
+// @Tags         integration
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     ApiKeyAuth
+// @Param        id        path    string                true  "UUID товара"
+// @Param        If-Match  header  string                true  "ETag товара (поле version из списка товаров)"
+// @Param        price     body    response.UpdatePrice  true  "Новая цена"
+// @Success      200  {object}  map[string]string  "Цена изменена"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Ключ недействителен"
+// @Failure      403  {object}  response.Problem  "У ключа нет нужного права"
+// @Failure      404  {object}  response.Problem  "Товар поставщика не найден"
+// @Failure      412  {object}  response.Problem  "Товар изменён после чтения"
+// @Failure      428  {object}  response.Problem  "Не передан заголовок If-Match"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /integration/product/{id}/price [patch]
+func (h *Handler) integrationUpdatePrice(c *gin.Context) {
+	key, _ := currentAPIKey(c)
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var priceReq response.UpdatePrice
+
+	if err := c.ShouldBindJSON(&priceReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	if err := h.services.UpdateSupplierProductPrice(c, key.SupplierID, productID, *priceReq.Price, version); err != nil {
+		newErrorResponse(c, err, "product.price_update_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "product.price_updated")})
+}
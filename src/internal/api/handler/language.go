@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"src/internal/i18n"
+)
+
+const languageCtx = "language"
+
+// negotiateLanguage выбирает язык сообщений по заголовку Accept-Language
+// и сохраняет его в контексте запроса.
+func (h *Handler) negotiateLanguage(c *gin.Context) {
+	lang := i18n.Negotiate(c.GetHeader("Accept-Language"), h.defaultLanguage)
+	c.Set(languageCtx, lang)
+	c.Header("Content-Language", lang)
+	c.Header("Vary", "Accept-Language")
+	c.Next()
+}
+
+// t переводит сообщение из каталога на язык текущего запроса.
+func t(c *gin.Context, key string, args ...any) string {
+	return i18n.Translate(c.GetString(languageCtx), key, args...)
+}
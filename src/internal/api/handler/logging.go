@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"src/internal/logger"
+	"time"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// requestIDRe ограничивает идентификаторы, принимаемые от клиента, чтобы в журнал не попадали
+// произвольные строки.
+var requestIDRe = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// assignRequestID берёт идентификатор запроса из заголовка X-Request-ID или создаёт новый,
+// возвращает его в ответе и сохраняет в контексте запроса для журнала.
+func assignRequestID(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !requestIDRe.MatchString(id) {
+		id = uuid.NewString()
+	}
+
+	c.Header(requestIDHeader, id)
+	c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), id))
+	c.Next()
+}
+
+// logRequests пишет в журнал запись о каждом обработанном запросе. Ответы 5xx
+// записываются с уровнем error, 4xx — warn.
+func logRequests(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	status := c.Writer.Status()
+	level := slog.LevelInfo
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	slog.LogAttrs(c, level, "request",
+		slog.String("method", c.Request.Method),
+		slog.String("route", route(c)),
+		slog.String("path", c.Request.URL.Path),
+		slog.Int("status", status),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("bytes", c.Writer.Size()),
+		slog.String("client_ip", c.ClientIP()),
+	)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+)
+
+// @Summary      Мой профиль
+// @Description  Возвращает профиль и адрес клиента, выполнившего вход. ETag ответа — версия профиля,
+// @Description  версия адреса для изменения адреса передаётся в поле address.version.
+// @Tags         me
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Success      200  {object}  response.ProfileResponse
+// @Header       200  {string}  ETag  "Версия профиля для заголовка If-Match"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Клиент не найден"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /me [get]
+func (h *Handler) getMe(c *gin.Context) {
+	principal, _ := currentPrincipal(c)
+
+	user, err := h.services.GetUserByID(c, principal.ID)
+	if err != nil {
+		newErrorResponse(c, err, "me.get_failed")
+		return
+	}
+
+	address, err := h.services.GetUserAddress(c, principal.ID)
+	if err != nil {
+		newErrorResponse(c, err, "me.get_failed")
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, mapper.ToProfileResponse(user, address))
+}
+
+// @Summary      Изменить мой профиль
+// @Description  Изменяет имя, фамилию, дату рождения и пол клиента, выполнившего вход.
+// @Description  If-Match должен содержать ETag, полученный из GET /me.
+// @Tags         me
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        If-Match  header  string                  true  "ETag профиля"
+// @Param        profile   body    response.UpdateProfile  true  "Новые данные профиля"
+// @Success      200  {object}  map[string]string  "Профиль изменён"
+// @Header       200  {string}  ETag  "Новая версия профиля"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Клиент не найден"
+// @Failure      412  {object}  response.Problem  "Профиль изменён после чтения"
+// @Failure      428  {object}  response.Problem  "Не передан заголовок If-Match"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /me [put]
+func (h *Handler) updateMe(c *gin.Context) {
+	principal, _ := currentPrincipal(c)
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var profileReq response.UpdateProfile
+
+	if err := c.ShouldBindJSON(&profileReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	user, err := mapper.ToProfileModel(profileReq)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
+	user.ID = principal.ID
+	user.Version = version
+
+	user, err = h.services.UpdateUser(c, user)
+	if err != nil {
+		newErrorResponse(c, err, "me.update_failed")
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "me.updated")})
+}
+
+// @Summary      Изменить мой адрес
+// @Description  Изменяет адрес клиента, выполнившего вход. If-Match должен содержать версию адреса
+// @Description  из поля address.version ответа GET /me.
+// @Tags         me
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        If-Match  header  string                        true  "ETag адреса"
+// @Param        address   body    response.CreateUpdateAddress  true  "Новый адрес"
+// @Success      200  {object}  map[string]string  "Адрес успешно изменен"
+// @Header       200  {string}  ETag  "Новая версия адреса"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Клиент не найден"
+// @Failure      412  {object}  response.Problem  "Адрес изменён после чтения"
+// @Failure      428  {object}  response.Problem  "Не передан заголовок If-Match"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /me/address [put]
+func (h *Handler) updateMyAddress(c *gin.Context) {
+	principal, _ := currentPrincipal(c)
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var addressReq response.CreateUpdateAddress
+
+	if err := c.ShouldBindJSON(&addressReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	address := mapper.ToAddressModel(addressReq)
+	address.Version = version
+
+	address, err := h.services.UpdateUserAddress(c, principal.ID, address)
+	if err != nil {
+		newErrorResponse(c, err, "user.address_update_failed")
+		return
+	}
+
+	setETag(c, address.Version)
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "user.address_updated")})
+}
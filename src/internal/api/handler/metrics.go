@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"src/internal/metrics"
+	"time"
+)
+
+// unmatchedRoute подставляется вместо шаблона маршрута для запросов, не попавших ни в один маршрут.
+const unmatchedRoute = "unmatched"
+
+// observeRequests учитывает каждый запрос в метриках HTTP по шаблону маршрута и статусу ответа.
+func observeRequests(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	metrics.ObserveRequest(c.Request.Method, route(c), c.Writer.Status(), time.Since(start))
+}
+
+// traceable исключает из трассировки служебные маршруты, которые опрашиваются постоянно.
+func traceable(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/metrics", "/healthz", "/readyz":
+		return false
+	}
+	return true
+}
+
+func route(c *gin.Context) string {
+	if path := c.FullPath(); path != "" {
+		return path
+	}
+	return unmatchedRoute
+}
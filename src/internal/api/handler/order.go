@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+)
+
+// @Summary      Оформить заказ
+// @Description  Атомарно списывает со склада все позиции заказа и создаёт заказ. Если хотя бы одного товара недостаточно, заказ отклоняется целиком
+// @Tags         orders
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        order  body  response.Checkout  true  "Клиент и позиции заказа"
+// @Success      201  {object}  response.OrderResponse
+// @Failure      400  {object}  response.Problem  "Ошибка при разборе данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Объект не найден"
+// @Failure      422  {object}  response.Problem  "Недостаточно товара на складе"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /order/checkout [post]
+func (h *Handler) checkout(c *gin.Context) {
+	var checkoutReq response.Checkout
+
+	if err := c.ShouldBindJSON(&checkoutReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	clientID, err := uuid.Parse(checkoutReq.ClientID)
+	if err != nil {
+		newValidationErrorResponse(c, "client_id", "request.client_uuid")
+		return
+	}
+
+	items, err := mapper.ToOrderItemModels(checkoutReq)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
+
+	order, err := h.services.Checkout(c, clientID, items)
+	if err != nil {
+		newErrorResponse(c, err, "order.checkout_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToOrderResponse(order))
+}
+
+// @Summary      Получить заказ
+// @Description  Возвращает заказ с позициями по его UUID
+// @Tags         orders
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "UUID заказа"
+// @Success      200  {object}  response.OrderResponse
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при получении заказа"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /order/{id} [get]
+func (h *Handler) getOrder(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.order_uuid")
+		return
+	}
+
+	order, err := h.services.GetOrderByID(c, orderID)
+	if err != nil {
+		newErrorResponse(c, err, "order.get_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"order": mapper.ToOrderResponse(order),
+	})
+}
+
+// @Summary      Получить заказы клиента
+// @Description  Возвращает страницу заказов клиента, начиная с последних, и курсор следующей страницы
+// @Tags         orders
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id      path   string  true   "UUID клиента"
+// @Param        limit   query  int     false  "Количество заказов на странице, от 1 до 100 (по умолчанию 20)"
+// @Param        cursor  query  string  false  "Курсор следующей страницы из next_cursor"
+// @Success      200  {object}  response.OrderListResponse  "Страница заказов"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID или параметров страницы"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      500  {object}  response.Problem  "Ошибка при получении заказов"
+// @Router       /order/client/{id} [get]
+func (h *Handler) getClientOrders(c *gin.Context) {
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.client_uuid")
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	orders, next, err := h.services.GetClientOrders(c, clientID, page)
+	if err != nil {
+		newErrorResponse(c, err, "order.list_failed")
+		return
+	}
+
+	orderResponses := make([]response.OrderResponse, len(orders))
+	for i, order := range orders {
+		orderResponses[i] = mapper.ToOrderResponse(order)
+	}
+
+	c.JSON(http.StatusOK, response.OrderListResponse{
+		Orders: orderResponses,
+		Page:   pageResponse(next),
+	})
+}
+
+// @Summary      Изменить статус заказа
+// @Description  Переводит заказ в новый статус (created -> paid -> shipped, отмена из created или paid). При отмене товары возвращаются на склад
+// @Tags         orders
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id       path    string                      true   "UUID заказа"
+// @Param        status   body    response.UpdateOrderStatus  true   "Новый статус"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Объект не найден"
+// @Failure      409  {object}  response.Problem  "Недопустимый переход статуса"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /order/{id}/status [patch]
+func (h *Handler) updateOrderStatus(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.order_uuid")
+		return
+	}
+
+	var statusReq response.UpdateOrderStatus
+
+	if err := c.ShouldBindJSON(&statusReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	err = h.services.UpdateOrderStatus(c, orderID, statusReq.Status, actor(c))
+	if err != nil {
+		newErrorResponse(c, err, "order.status_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "order.status_changed")})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"src/internal/api/response"
+	"src/internal/repository/model"
+	"strconv"
+)
+
+const defaultPageLimit = 20
+
+// cursorPayload — содержимое курсора. Клиенту он передаётся непрозрачной строкой
+// base64url, чтобы формат можно было менять, не ломая клиентов.
+type cursorPayload struct {
+	Sort  string    `json:"s"`
+	Desc  bool      `json:"d,omitempty"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// parsePage разбирает параметры limit и cursor. Размер страницы и соответствие курсора
+// порядку списка проверяет сервис.
+func parsePage(c *gin.Context) (model.Page, bool) {
+	page := model.Page{Limit: defaultPageLimit}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			newValidationErrorResponse(c, "limit", "request.limit_invalid")
+			return model.Page{}, false
+		}
+		page.Limit = limit
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := decodeCursor(value)
+		if err != nil {
+			newValidationErrorResponse(c, "cursor", "request.cursor_invalid")
+			return model.Page{}, false
+		}
+		page.After = &cursor
+	}
+
+	return page, true
+}
+
+func decodeCursor(value string) (model.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return model.Cursor{}, err
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return model.Cursor{}, err
+	}
+
+	return model.Cursor{Sort: payload.Sort, Desc: payload.Desc, Value: payload.Value, ID: payload.ID}, nil
+}
+
+// pageResponse описывает продолжение списка для ответа: курсор следующей страницы
+// или null, если страница последняя.
+func pageResponse(next *model.Cursor) response.Page {
+	if next == nil {
+		return response.Page{}
+	}
+
+	data, _ := json.Marshal(cursorPayload{Sort: next.Sort, Desc: next.Desc, Value: next.Value, ID: next.ID})
+	cursor := base64.RawURLEncoding.EncodeToString(data)
+	return response.Page{NextCursor: &cursor, HasMore: true}
+}
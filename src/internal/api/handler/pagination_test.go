@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/base64"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+	"src/internal/api/response"
+	"src/internal/repository/model"
+	"testing"
+)
+
+func TestPageResponseRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor model.Cursor
+	}{
+		{name: "ascending", cursor: model.Cursor{Sort: "name", Value: "Чайник", ID: uuid.New()}},
+		{name: "descending", cursor: model.Cursor{Sort: "price", Desc: true, Value: "99.5", ID: uuid.New()}},
+		{name: "empty value", cursor: model.Cursor{Sort: "name", ID: uuid.New()}},
+		{name: "value with url characters", cursor: model.Cursor{Sort: "name", Value: "a+b/c?d=e&f", ID: uuid.New()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := pageResponse(&tt.cursor)
+			if !page.HasMore || page.NextCursor == nil {
+				t.Fatalf("page = %+v, want next cursor", page)
+			}
+
+			got, err := decodeCursor(*page.NextCursor)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+			if got != tt.cursor {
+				t.Fatalf("cursor = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestPageResponseLastPage(t *testing.T) {
+	page := pageResponse(nil)
+
+	if page.HasMore || page.NextCursor != nil {
+		t.Fatalf("page = %+v, want last page", page)
+	}
+}
+
+func TestParsePage(t *testing.T) {
+	cursor := model.Cursor{Sort: "name", Value: "Чайник", ID: uuid.New()}
+	valid := *pageResponse(&cursor).NextCursor
+
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+
+	tests := []struct {
+		name      string
+		query     url.Values
+		wantOK    bool
+		wantLimit int
+		wantAfter *model.Cursor
+	}{
+		{name: "defaults", query: url.Values{}, wantOK: true, wantLimit: defaultPageLimit},
+		{name: "limit", query: url.Values{"limit": {"5"}}, wantOK: true, wantLimit: 5},
+		// Диапазон размера страницы проверяет сервис.
+		{name: "limit out of range", query: url.Values{"limit": {"0"}}, wantOK: true, wantLimit: 0},
+		{name: "limit not a number", query: url.Values{"limit": {"ten"}}},
+		{name: "cursor", query: url.Values{"cursor": {valid}}, wantOK: true, wantLimit: defaultPageLimit, wantAfter: &cursor},
+		{name: "cursor not base64", query: url.Values{"cursor": {"не курсор"}}},
+		{name: "cursor with padding", query: url.Values{"cursor": {base64.URLEncoding.EncodeToString([]byte(`{"s":"price"}`))}}},
+		{name: "cursor not json", query: url.Values{"cursor": {encode("name:Чайник")}}},
+		{name: "cursor json array", query: url.Values{"cursor": {encode(`["name","Чайник"]`)}}},
+		{name: "cursor with invalid id", query: url.Values{"cursor": {encode(`{"s":"name","v":"a","id":"42"}`)}}},
+		{name: "cursor with wrong value type", query: url.Values{"cursor": {encode(`{"s":"name","v":1,"id":"` + uuid.NewString() + `"}`)}}},
+		{name: "truncated cursor", query: url.Values{"cursor": {valid[:len(valid)/2]}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newTestContext(http.MethodGet, "/list?"+tt.query.Encode(), "")
+
+			page, ok := parsePage(c)
+
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if recorder.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+				}
+				if problem := decodeProblem(t, recorder); problem.Code != response.CodeValidationFailed {
+					t.Fatalf("code = %s, want %s", problem.Code, response.CodeValidationFailed)
+				}
+				return
+			}
+
+			if page.Limit != tt.wantLimit {
+				t.Fatalf("limit = %d, want %d", page.Limit, tt.wantLimit)
+			}
+			switch {
+			case tt.wantAfter == nil && page.After != nil:
+				t.Fatalf("after = %+v, want nil", *page.After)
+			case tt.wantAfter != nil && (page.After == nil || *page.After != *tt.wantAfter):
+				t.Fatalf("after = %+v, want %+v", page.After, *tt.wantAfter)
+			}
+		})
+	}
+}
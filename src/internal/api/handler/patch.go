@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+	"src/internal/api/response"
+)
+
+// bindMergePatch применяет тело запроса в формате JSON Merge Patch (RFC 7386) к текущему
+// представлению ресурса current, записывает результат в target и проверяет его по тегам
+// binding. Отсутствующие в патче поля сохраняют текущие значения, null удаляет значение
+// (для обязательных полей это ошибка проверки). Поля, которых нет в current, менять нельзя.
+// При ошибке ответ уже отправлен.
+func bindMergePatch(c *gin.Context, current, target any) bool {
+	body, err := c.GetRawData()
+	if err != nil {
+		newProblemResponse(c, http.StatusBadRequest, response.CodeMalformedBody, t(c, "request.malformed_body"))
+		return false
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		newProblemResponse(c, http.StatusBadRequest, response.CodeMalformedBody, t(c, "request.malformed_body"))
+		return false
+	}
+
+	document, err := json.Marshal(current)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return false
+	}
+
+	var resource map[string]any
+	if err := json.Unmarshal(document, &resource); err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return false
+	}
+
+	for field := range patch {
+		if _, ok := resource[field]; !ok {
+			newValidationErrorResponse(c, field, "request.field_not_patchable")
+			return false
+		}
+	}
+
+	merged, err := json.Marshal(mergePatch(resource, patch))
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return false
+	}
+
+	if err := json.Unmarshal(merged, target); err != nil {
+		newBindErrorResponse(c, err)
+		return false
+	}
+
+	if err := binding.Validator.ValidateStruct(target); err != nil {
+		newBindErrorResponse(c, err)
+		return false
+	}
+
+	return true
+}
+
+// mergePatch реализует алгоритм MergePatch из RFC 7386.
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"src/internal/api/response"
+	"strings"
+	"testing"
+)
+
+// TestMergePatch проверяет алгоритм на примерах из приложения A RFC 7386.
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target+" + "+tt.patch, func(t *testing.T) {
+			got := mergePatch(decodeJSON(t, tt.target), decodeJSON(t, tt.patch))
+
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Fatalf("mergePatch = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBindMergePatch(t *testing.T) {
+	current := response.UpdateSupplier{Name: "ООО Ромашка", Phone: "+79990000000"}
+
+	tests := []struct {
+		name     string
+		body     string
+		want     response.UpdateSupplier
+		wantOK   bool
+		wantCode string
+	}{
+		{
+			name:   "empty patch keeps resource",
+			body:   `{}`,
+			want:   current,
+			wantOK: true,
+		},
+		{
+			name:   "one field",
+			body:   `{"name":"ООО Лютик"}`,
+			want:   response.UpdateSupplier{Name: "ООО Лютик", Phone: current.Phone},
+			wantOK: true,
+		},
+		{
+			name:   "all fields",
+			body:   `{"name":"ООО Лютик","phone_number":"+79991111111"}`,
+			want:   response.UpdateSupplier{Name: "ООО Лютик", Phone: "+79991111111"},
+			wantOK: true,
+		},
+		{name: "null removes required field", body: `{"phone_number":null}`, wantCode: response.CodeValidationFailed},
+		{name: "value breaks binding rule", body: `{"name":"` + strings.Repeat("я", 256) + `"}`, wantCode: response.CodeValidationFailed},
+		{name: "field not in resource", body: `{"id":"5"}`, wantCode: response.CodeValidationFailed},
+		{name: "wrong value type", body: `{"name":5}`, wantCode: response.CodeValidationFailed},
+		{name: "malformed json", body: `{"name":`, wantCode: response.CodeMalformedBody},
+		{name: "array instead of object", body: `["name"]`, wantCode: response.CodeMalformedBody},
+		{name: "null document", body: `null`, wantCode: response.CodeMalformedBody},
+		{name: "empty body", body: ``, wantCode: response.CodeMalformedBody},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, recorder := newTestContext(http.MethodPatch, "/supplier/1", tt.body)
+
+			var got response.UpdateSupplier
+			ok := bindMergePatch(c, current, &got)
+
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v: %s", ok, tt.wantOK, recorder.Body.String())
+			}
+			if ok {
+				if got != tt.want {
+					t.Fatalf("result = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+			}
+			if problem := decodeProblem(t, recorder); problem.Code != tt.wantCode {
+				t.Fatalf("code = %s, want %s", problem.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func decodeJSON(t *testing.T, document string) any {
+	t.Helper()
+
+	var value any
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		t.Fatalf("некорректный JSON в тесте %s: %v", document, err)
+	}
+	return value
+}
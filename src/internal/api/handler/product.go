@@ -1,127 +1,175 @@
 package handler
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"math"
 	"net/http"
 	"src/internal/api/response"
 	"src/internal/middleware/mapper"
+	"src/internal/repository/model"
 	"strconv"
+	"time"
 )
 
 // @Summary      Создать товар
 // @Description  Добавляет новый товар в систему
 // @Tags         products
 // @Accept       json
-// @Produce      json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
 // @Param        product  body  response.CreateProduct  true  "Данные товара"
 // @Success      201  {object}  map[string]string
-// @Failure      400  {object}  map[string]string  "Ошибка при разборе данных"
-// @Failure      500  {object}  map[string]string  "Ошибка при создании товара"
+// @Failure      400  {object}  response.Problem  "Ошибка при разборе данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      500  {object}  response.Problem  "Ошибка при создании товара"
 // @Router       /product/create [post]
 func (h *Handler) createProduct(c *gin.Context) {
 	var productReq response.CreateProduct
 
 	if err := c.ShouldBindJSON(&productReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
-	product := mapper.ToProductModel(productReq)
+	product, err := mapper.ToProductModel(productReq)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
 
-	id, err := h.services.CreateProduct(c, product)
+	id, err := h.services.CreateProduct(c, product, actor(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Не удалось создать товар: %s", err.Error())})
+		newErrorResponse(c, err, "product.create_failed")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Товар успешно создан",
+		"message": t(c, "product.created"),
 		"id":      id.String(),
 	})
 }
 
 // @Summary      Уменьшить количество товара на складе
-// @Description  Уменьшает количество указанного товара на складе
+// @Description  Уменьшает количество указанного товара на складе, если товар не менялся с версии из If-Match
 // @Tags         products
-// @Produce      json
-// @Param        id        query  string  true  "UUID товара"
-// @Param        quantity  query  int     true  "Количество для уменьшения"
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id        query   string  true  "UUID товара"
+// @Param        quantity  query   int     true  "Количество для уменьшения"
+// @Param        If-Match  header  string  true  "ETag товара"
 // @Success      200  {object}  map[string]string
-// @Failure      400  {object}  map[string]string  "Неверный формат UUID или количества"
-// @Failure      404  {object}  map[string]string  "Ошибка при уменьшении товара"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID или количества"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при уменьшении товара"
+// @Failure      412  {object}  response.Problem  "Товар изменён после чтения"
+// @Failure      422  {object}  response.Problem  "Недостаточно товара на складе"
+// @Failure      428  {object}  response.Problem  "Не передан заголовок If-Match"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
 // @Router       /product/updateQuantity [patch]
 func (h *Handler) reduceStock(c *gin.Context) {
 	productIDParam := c.Query("id")
 	productID, err := uuid.Parse(productIDParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID"})
+		newValidationErrorResponse(c, "id", "request.product_uuid")
 		return
 	}
 
 	quantityParam := c.Query("quantity")
 	quantity, err := strconv.Atoi(quantityParam)
 	if err != nil || quantity <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Количество товара должно быть положительным числом"})
+		newValidationErrorResponse(c, "quantity", "request.quantity_positive")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
-	err = h.services.ReduceStock(c, productID, quantity)
+	err = h.services.ReduceStock(c, productID, quantity, version, actor(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		newErrorResponse(c, err, "product.reduce_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Количество товара уменьшено"})
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "product.reduced")})
 }
 
 // @Summary      Получить товар по ID
 // @Description  Возвращает информацию о товаре по его UUID
 // @Tags         products
-// @Produce      json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
 // @Param        id  path  string  true  "UUID товара"
 // @Success      200  {object}  response.ProductResponse
-// @Failure      400  {object}  map[string]string  "Некорректный формат ID"
-// @Failure      404  {object}  map[string]string  "Ошибка при получении товара"
+// @Header       200  {string}  ETag  "Версия товара для заголовка If-Match"
+// @Failure      400  {object}  response.Problem  "Некорректный формат ID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при получении товара"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
 // @Router       /product/{id} [get]
 func (h *Handler) getProduct(c *gin.Context) {
 	productIDStr := c.Param("id")
 	if productIDStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID товара обязателен"})
+		newValidationErrorResponse(c, "id", "request.product_id_required")
 		return
 	}
 
 	productID, err := uuid.Parse(productIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный формат ID"})
+		newValidationErrorResponse(c, "id", "request.product_uuid")
 		return
 	}
 
 	product, err := h.services.GetProductById(c, productID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении товара: %s", err.Error())})
+		newErrorResponse(c, err, "product.get_failed")
 		return
 	}
 
 	productResponse := mapper.ToProductResponse(product)
 
+	setETag(c, product.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"product": productResponse,
 	})
 }
 
 // @Summary      Получить список товаров
-// @Description  Возвращает список всех товаров
+// @Description  Возвращает страницу товаров с фильтрацией, сортировкой и общим числом подходящих товаров
 // @Tags         products
-// @Produce      json
-// @Success      200  {array}   response.ProductResponse
-// @Failure      404  {object}  map[string]string  "Ошибка при получении товаров"
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        category         query  string   false  "Категория"
+// @Param        supplier_id      query  string   false  "UUID поставщика"
+// @Param        min_price        query  number   false  "Минимальная цена"
+// @Param        max_price        query  number   false  "Максимальная цена"
+// @Param        in_stock         query  boolean  false  "Только товары, доступные к продаже"
+// @Param        updated_since    query  string   false  "Изменённые не раньше момента (RFC 3339)"
+// @Param        sort             query  string   false  "Поле сортировки"  Enums(name, price, stock, last_update_date)
+// @Param        order            query  string   false  "Направление сортировки"  Enums(asc, desc)
+// @Param        limit            query  int      false  "Размер страницы (1-100)"  default(20)
+// @Param        cursor           query  string   false  "Курсор следующей страницы из next_cursor"
+// @Param        include_deleted  query  boolean  false  "Включить удалённые товары (только для администратора)"
+// @Success      200  {object}  response.ProductListResponse
+// @Failure      400  {object}  response.Problem  "Неверные параметры запроса"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
 // @Router       /product/productList [get]
 func (h *Handler) getProductList(c *gin.Context) {
-	products, err := h.services.GetProductList(c)
+	filter, ok := parseProductFilter(c)
+	if !ok {
+		return
+	}
+
+	products, total, next, err := h.services.GetProductList(c, filter)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении товара: %s", err.Error())})
+		newErrorResponse(c, err, "product.list_failed")
 		return
 	}
 
@@ -130,33 +178,268 @@ func (h *Handler) getProductList(c *gin.Context) {
 		productResponses[i] = mapper.ToProductResponse(product)
 	}
 
+	c.JSON(http.StatusOK, response.ProductListResponse{
+		Products: productResponses,
+		Total:    total,
+		Page:     pageResponse(next),
+	})
+}
+
+// @Summary      Поиск товаров
+// @Description  Ищет товары по названию и категории: полнотекстовый поиск на русском и английском
+// @Description  с учётом словоформ и поиск по триграммам для запросов с опечатками.
+// @Description  Результаты упорядочены по релевантности. Поля highlight — HTML: текст экранирован,
+// @Description  совпадения подсвечены тегом <mark>.
+// @Tags         products
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        q       query  string  true   "Поисковый запрос"
+// @Param        limit   query  int     false  "Размер страницы (1-100)"  default(20)
+// @Param        cursor  query  string  false  "Курсор следующей страницы из next_cursor"
+// @Success      200  {object}  response.ProductSearchResponse
+// @Failure      400  {object}  response.Problem  "Неверные параметры запроса"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /product/search [get]
+func (h *Handler) searchProducts(c *gin.Context) {
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	matches, next, err := h.services.SearchProducts(c, model.ProductSearch{Query: c.Query("q"), Page: page})
+	if err != nil {
+		newErrorResponse(c, err, "product.search_failed")
+		return
+	}
+
+	results := make([]response.ProductMatchResponse, len(matches))
+	for i, match := range matches {
+		results[i] = mapper.ToProductMatchResponse(match)
+	}
+
+	c.JSON(http.StatusOK, response.ProductSearchResponse{
+		Results: results,
+		Page:    pageResponse(next),
+	})
+}
+
+// @Summary      Изменить товар
+// @Description  Частично изменяет товар: тело в формате JSON Merge Patch содержит только изменяемые поля
+// @Description  (name, category, price, supplierID). Дата изменения товара обновляется.
+// @Description  If-Match должен содержать ETag, полученный при чтении товара.
+// @Tags         products
+// @Accept       application/merge-patch+json,json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id        path    string                  true  "UUID товара"
+// @Param        If-Match  header  string                  true  "ETag товара"
+// @Param        patch     body    response.UpdateProduct  true  "Изменяемые поля товара"
+// @Success      200  {object}  map[string]response.ProductResponse  "Изменённый товар"
+// @Header       200  {string}  ETag  "Новая версия товара"
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Товар не найден"
+// @Failure      412  {object}  response.Problem  "Товар изменён после чтения"
+// @Failure      428  {object}  response.Problem  "Не передан заголовок If-Match"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /product/{id} [patch]
+func (h *Handler) patchProduct(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.services.GetProductById(c, productID)
+	if err != nil {
+		newErrorResponse(c, err, "product.get_failed")
+		return
+	}
+
+	var productReq response.UpdateProduct
+	if !bindMergePatch(c, mapper.ToUpdateProduct(product), &productReq) {
+		return
+	}
+
+	update, err := mapper.ToUpdatedProductModel(productReq)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
+	update.ID = productID
+	update.Version = version
+
+	product, err = h.services.UpdateProduct(c, update)
+	if err != nil {
+		newErrorResponse(c, err, "product.update_failed")
+		return
+	}
+
+	setETag(c, product.Version)
 	c.JSON(http.StatusOK, gin.H{
-		"products": productResponses,
+		"product": mapper.ToProductResponse(product),
 	})
 }
 
 // @Summary      Удалить товар
-// @Description  Удаляет товар по его UUID
+// @Description  Помечает товар удалённым, если он не менялся с версии из If-Match.
+// @Description  Товар можно восстановить до окончательной очистки по истечении срока хранения.
+// @Description  Товары из заказов не очищаются никогда, чтобы не терять историю заказов.
 // @Tags         products
-// @Produce      json
-// @Param        id  path  string  true  "UUID товара"
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id        path    string  true  "UUID товара"
+// @Param        If-Match  header  string  true  "ETag товара"
 // @Success      200  {object}  map[string]string
-// @Failure      400  {object}  map[string]string  "Неверный формат UUID"
-// @Failure      404  {object}  map[string]string  "Ошибка при удалении товара"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при удалении товара"
+// @Failure      409  {object}  response.Problem  "Конфликт с текущим состоянием данных"
+// @Failure      412  {object}  response.Problem  "Товар изменён после чтения"
+// @Failure      428  {object}  response.Problem  "Не передан заголовок If-Match"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
 // @Router       /product/delete/{id} [delete]
 func (h *Handler) deleteProduct(c *gin.Context) {
 	productIDStr := c.Param("id")
 	productID, err := uuid.Parse(productIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID товара"})
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
-	err = h.services.RemoveProduct(c, productID)
+	err = h.services.RemoveProduct(c, productID, version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при удалении товара: %s", err.Error())})
+		newErrorResponse(c, err, "product.delete_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Товар успешно удалён"})
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "product.deleted")})
+}
+
+// @Summary      Восстановить товар
+// @Description  Снимает с товара пометку об удалении. Восстановить можно только товар,
+// @Description  ещё не удалённый окончательно по истечении срока хранения.
+// @Tags         products
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "UUID товара"
+// @Success      200  {object}  map[string]response.ProductResponse  "Восстановленный товар"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Товар не найден"
+// @Failure      409  {object}  response.Problem  "Товар не удалён"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /product/{id}/restore [post]
+func (h *Handler) restoreProduct(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	product, err := h.services.RestoreProduct(c, productID)
+	if err != nil {
+		newErrorResponse(c, err, "product.restore_failed")
+		return
+	}
+
+	setETag(c, product.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"product": mapper.ToProductResponse(product),
+	})
+}
+
+// parseProductFilter разбирает query-параметры списка товаров. Диапазоны и допустимость
+// значений проверяет сервис.
+func parseProductFilter(c *gin.Context) (model.ProductFilter, bool) {
+	page, ok := parsePage(c)
+	if !ok {
+		return model.ProductFilter{}, false
+	}
+
+	filter := model.ProductFilter{
+		Category: c.Query("category"),
+		Sort:     c.Query("sort"),
+		Page:     page,
+	}
+
+	if value := c.Query("supplier_id"); value != "" {
+		supplierID, err := uuid.Parse(value)
+		if err != nil {
+			newValidationErrorResponse(c, "supplier_id", "request.supplier_uuid")
+			return model.ProductFilter{}, false
+		}
+		filter.SupplierID = &supplierID
+	}
+
+	if filter.MinPrice, ok = queryPrice(c, "min_price"); !ok {
+		return model.ProductFilter{}, false
+	}
+	if filter.MaxPrice, ok = queryPrice(c, "max_price"); !ok {
+		return model.ProductFilter{}, false
+	}
+
+	if value := c.Query("in_stock"); value != "" {
+		inStock, err := strconv.ParseBool(value)
+		if err != nil {
+			newValidationErrorResponse(c, "in_stock", "request.bool_invalid", "in_stock")
+			return model.ProductFilter{}, false
+		}
+		filter.InStock = inStock
+	}
+
+	if value := c.Query("updated_since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			newValidationErrorResponse(c, "updated_since", "request.datetime_invalid", "updated_since")
+			return model.ProductFilter{}, false
+		}
+		filter.UpdatedSince = &since
+	}
+
+	if filter.IncludeDeleted, ok = parseIncludeDeleted(c); !ok {
+		return model.ProductFilter{}, false
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		newValidationErrorResponse(c, "order", "validation.oneof", "asc desc")
+		return model.ProductFilter{}, false
+	}
+
+	return filter, true
+}
+
+func queryPrice(c *gin.Context, field string) (*float64, bool) {
+	value := c.Query(field)
+	if value == "" {
+		return nil, true
+	}
+
+	// ParseFloat принимает "NaN" и "Inf", но ни одна цена с ними не сравнима.
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(price) || math.IsInf(price, 0) || price < 0 {
+		newValidationErrorResponse(c, field, "request.price_invalid", field)
+		return nil, false
+	}
+
+	return &price, true
 }
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"src/internal/api/response"
+	"testing"
+)
+
+func TestQueryPrice(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   *float64
+		wantOK bool
+	}{
+		{name: "missing", wantOK: true},
+		{name: "integer", value: "10", want: ptr(10.0), wantOK: true},
+		{name: "fraction", value: "99.5", want: ptr(99.5), wantOK: true},
+		{name: "zero", value: "0", want: ptr(0.0), wantOK: true},
+		{name: "negative", value: "-1"},
+		{name: "not a number", value: "дёшево"},
+		{name: "nan", value: "NaN"},
+		{name: "infinity", value: "Inf"},
+		{name: "negative infinity", value: "-Infinity"},
+		{name: "overflow", value: "1e400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/product/productList"
+			if tt.value != "" {
+				target += "?min_price=" + tt.value
+			}
+			c, recorder := newTestContext(http.MethodGet, target, "")
+
+			price, ok := queryPrice(c, "min_price")
+
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if recorder.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+				}
+				if problem := decodeProblem(t, recorder); problem.Code != response.CodeValidationFailed {
+					t.Fatalf("code = %s, want %s", problem.Code, response.CodeValidationFailed)
+				}
+				return
+			}
+
+			switch {
+			case tt.want == nil && price != nil:
+				t.Fatalf("price = %v, want nil", *price)
+			case tt.want != nil && (price == nil || *price != *tt.want):
+				t.Fatalf("price = %v, want %v", price, *tt.want)
+			}
+		})
+	}
+}
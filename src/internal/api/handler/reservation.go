@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+	"time"
+)
+
+// @Summary      Зарезервировать товар
+// @Description  Удерживает указанное количество товара на время TTL. Если ttl_seconds не указан, используется значение по умолчанию
+// @Tags         reservations
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        reservation  body  response.CreateReservation  true  "Товар, количество и срок резерва"
+// @Success      201  {object}  response.ReservationResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Объект не найден"
+// @Failure      422  {object}  response.Problem  "Недостаточно товара на складе"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /reservation/create [post]
+func (h *Handler) createReservation(c *gin.Context) {
+	var reservationReq response.CreateReservation
+
+	if err := c.ShouldBindJSON(&reservationReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	productID, err := uuid.Parse(reservationReq.ProductID)
+	if err != nil {
+		newValidationErrorResponse(c, "product_id", "request.product_uuid")
+		return
+	}
+
+	ttl := time.Duration(reservationReq.TTLSeconds) * time.Second
+
+	reservation, err := h.services.Reserve(c, productID, reservationReq.Quantity, ttl)
+	if err != nil {
+		newErrorResponse(c, err, "reservation.create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToReservationResponse(reservation))
+}
+
+// @Summary      Получить резерв
+// @Description  Возвращает резерв по его UUID
+// @Tags         reservations
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "UUID резерва"
+// @Success      200  {object}  response.ReservationResponse
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при получении резерва"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /reservation/{id} [get]
+func (h *Handler) getReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.reservation_uuid")
+		return
+	}
+
+	reservation, err := h.services.GetReservationByID(c, reservationID)
+	if err != nil {
+		newErrorResponse(c, err, "reservation.get_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reservation": mapper.ToReservationResponse(reservation),
+	})
+}
+
+// @Summary      Подтвердить резерв
+// @Description  Снимает резерв и списывает зарезервированное количество со склада
+// @Tags         reservations
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id       path    string  true   "UUID резерва"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Объект не найден"
+// @Failure      409  {object}  response.Problem  "Резерв неактивен или истёк"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /reservation/{id}/confirm [post]
+func (h *Handler) confirmReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.reservation_uuid")
+		return
+	}
+
+	err = h.services.ConfirmReservation(c, reservationID, actor(c))
+	if err != nil {
+		newErrorResponse(c, err, "reservation.confirm_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "reservation.confirmed")})
+}
+
+// @Summary      Снять резерв
+// @Description  Освобождает зарезервированный товар
+// @Tags         reservations
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "UUID резерва"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Объект не найден"
+// @Failure      409  {object}  response.Problem  "Резерв неактивен"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /reservation/{id}/release [post]
+func (h *Handler) releaseReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.reservation_uuid")
+		return
+	}
+
+	err = h.services.ReleaseReservation(c, reservationID)
+	if err != nil {
+		newErrorResponse(c, err, "reservation.release_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "reservation.released")})
+}
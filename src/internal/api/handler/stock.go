@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"src/internal/api/response"
+	"src/internal/middleware/mapper"
+)
+
+// @Summary      Поступление товара
+// @Description  Увеличивает остаток товара и записывает движение с причиной restock
+// @Tags         stock
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id       path    string            true   "UUID товара"
+// @Param        restock  body    response.Restock  true   "Количество поступившего товара"
+// @Success      200  {object}  response.StockResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при изменении остатка"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /product/{id}/restock [post]
+func (h *Handler) restock(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	var restockReq response.Restock
+
+	if err := c.ShouldBindJSON(&restockReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	stock, err := h.services.Restock(c, productID, restockReq.Quantity, actor(c))
+	if err != nil {
+		newErrorResponse(c, err, "stock.change_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StockResponse{
+		ProductID:      productID.String(),
+		AvailableStock: stock,
+	})
+}
+
+// @Summary      Корректировка остатка
+// @Description  Изменяет остаток товара на указанную величину (инвентаризация) и записывает движение с причиной adjustment
+// @Tags         stock
+// @Accept       json
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id       path    string                true   "UUID товара"
+// @Param        adjust   body    response.AdjustStock  true   "Изменение остатка (может быть отрицательным)"
+// @Success      200  {object}  response.StockResponse
+// @Failure      400  {object}  response.Problem  "Ошибка в данных"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      404  {object}  response.Problem  "Ошибка при изменении остатка"
+// @Failure      422  {object}  response.Problem  "Недостаточно товара на складе"
+// @Failure      500  {object}  response.Problem  "Внутренняя ошибка сервера"
+// @Router       /product/{id}/adjustStock [post]
+func (h *Handler) adjustStock(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	var adjustReq response.AdjustStock
+
+	if err := c.ShouldBindJSON(&adjustReq); err != nil {
+		newBindErrorResponse(c, err)
+		return
+	}
+
+	stock, err := h.services.AdjustStock(c, productID, adjustReq.Delta, actor(c))
+	if err != nil {
+		newErrorResponse(c, err, "stock.change_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.StockResponse{
+		ProductID:      productID.String(),
+		AvailableStock: stock,
+	})
+}
+
+// @Summary      История движения товара
+// @Description  Возвращает страницу журнала изменений остатка товара, начиная с последних записей,
+// @Description  и курсор следующей страницы
+// @Tags         stock
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Param        id      path   string  true   "UUID товара"
+// @Param        limit   query  int     false  "Количество записей на странице, от 1 до 100 (по умолчанию 20)"
+// @Param        cursor  query  string  false  "Курсор следующей страницы из next_cursor"
+// @Success      200  {object}  response.StockMovementListResponse  "Страница журнала движения"
+// @Failure      400  {object}  response.Problem  "Неверный формат UUID или параметров страницы"
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Failure      500  {object}  response.Problem  "Ошибка при получении истории"
+// @Router       /product/{id}/stockMovements [get]
+func (h *Handler) getStockMovements(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.product_uuid")
+		return
+	}
+
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	movements, next, err := h.services.GetStockMovements(c, productID, page)
+	if err != nil {
+		newErrorResponse(c, err, "stock.movements_failed")
+		return
+	}
+
+	movementResponses := make([]response.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		movementResponses[i] = mapper.ToStockMovementResponse(movement)
+	}
+
+	c.JSON(http.StatusOK, response.StockMovementListResponse{
+		Movements: movementResponses,
+		Page:      pageResponse(next),
+	})
+}
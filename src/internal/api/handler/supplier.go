@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
@@ -13,17 +12,21 @@ import (
 // @Description Создает нового поставщика с указанным адресом
 // @Tags suppliers
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param supplier body response.CreateSupplier true "Данные нового поставщика"
 // @Success 201 {object} map[string]string "Поставщик успешно создан"
-// @Failure 400 {object} map[string]string "Ошибка в данных"
-// @Failure 500 {object} map[string]string "Не удалось создать поставщика"
+// @Failure 400 {object} response.Problem "Ошибка в данных"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 409 {object} response.Problem "Конфликт с текущим состоянием данных"
+// @Failure 500 {object} response.Problem "Не удалось создать поставщика"
 // @Router /supplier/create [post]
 func (h *Handler) createSupplier(c *gin.Context) {
 	var supplierReq response.CreateSupplier
 
 	if err := c.ShouldBindJSON(&supplierReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
@@ -32,92 +35,174 @@ func (h *Handler) createSupplier(c *gin.Context) {
 
 	id, err := h.services.AddSupplier(c, supplier, address)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Не удалось создать пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "supplier.create_failed")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Поставщик успешно создан",
+		"message": t(c, "supplier.created"),
 		"id":      id.String(),
 	})
 }
 
 // @Summary Обновить адрес поставщика
-// @Description Обновляет адрес поставщика по его ID
+// @Description Обновляет адрес поставщика по его ID. If-Match должен содержать ETag адреса,
+// @Description полученный из GET /supplier/{id}/address.
 // @Tags suppliers
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param id path string true "UUID поставщика"
+// @Param If-Match header string true "ETag адреса"
 // @Param address body response.CreateUpdateAddress true "Новый адрес поставщика"
 // @Success 200 {object} map[string]string "Адрес успешно изменен"
-// @Failure 400 {object} map[string]string "Ошибка в данных или некорректный UUID"
-// @Failure 404 {object} map[string]string "Ошибка при обновлении адреса"
+// @Header 200 {string} ETag "Новая версия адреса"
+// @Failure 400 {object} response.Problem "Ошибка в данных или некорректный UUID"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Ошибка при обновлении адреса"
+// @Failure 412 {object} response.Problem "Адрес изменён после чтения"
+// @Failure 428 {object} response.Problem "Не передан заголовок If-Match"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /supplier/updateAddress/{id} [put]
 func (h *Handler) updateSupplierAddress(c *gin.Context) {
 	supplierIDStr := c.Param("id")
 	supplierID, err := uuid.Parse(supplierIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID поставщика"})
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
 	var Address response.CreateUpdateAddress
 
 	if err := c.ShouldBindJSON(&Address); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
 	address := mapper.ToAddressModel(Address)
+	address.Version = version
 
-	err = h.services.UpdateSupplierAddress(c, supplierID, address)
+	address, err = h.services.UpdateSupplierAddress(c, supplierID, address)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при удалении поставщика: %s", err.Error())})
+		newErrorResponse(c, err, "supplier.address_update_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Адрес успешно изменен"})
+	setETag(c, address.Version)
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "supplier.address_updated")})
 }
 
 // @Summary Удалить поставщика
-// @Description Удаляет поставщика по его ID
+// @Description Помечает поставщика удалённым, если он не менялся с версии из If-Match.
+// @Description Товары поставщика сохраняют ссылку на него; поставщика можно восстановить
+// @Description до окончательной очистки по истечении срока хранения.
 // @Tags suppliers
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param id path string true "UUID поставщика"
+// @Param If-Match header string true "ETag поставщика"
 // @Success 200 {object} map[string]string "Поставщик успешно удалён"
-// @Failure 400 {object} map[string]string "Некорректный UUID поставщика"
-// @Failure 404 {object} map[string]string "Ошибка при удалении поставщика"
+// @Failure 400 {object} response.Problem "Некорректный UUID поставщика"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Ошибка при удалении поставщика"
+// @Failure 409 {object} response.Problem "Конфликт с текущим состоянием данных"
+// @Failure 412 {object} response.Problem "Поставщик изменён после чтения"
+// @Failure 428 {object} response.Problem "Не передан заголовок If-Match"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /supplier/delete/{id} [delete]
 func (h *Handler) deleteSupplier(c *gin.Context) {
 	supplierIDStr := c.Param("id")
 	supplierID, err := uuid.Parse(supplierIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID поставщика"})
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
 		return
 	}
 
-	err = h.services.RemoveSupplier(c, supplierID)
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	err = h.services.RemoveSupplier(c, supplierID, version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при удалении поставщика: %s", err.Error())})
+		newErrorResponse(c, err, "supplier.delete_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Поставщик успешно удалён"})
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "supplier.deleted")})
+}
+
+// @Summary Восстановить поставщика
+// @Description Снимает с поставщика пометку об удалении. Восстановить можно только поставщика,
+// @Description ещё не удалённого окончательно по истечении срока хранения.
+// @Tags suppliers
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID поставщика"
+// @Success 200 {object} map[string]response.SupplierResponse "Восстановленный поставщик"
+// @Failure 400 {object} response.Problem "Некорректный UUID поставщика"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Поставщик не найден"
+// @Failure 409 {object} response.Problem "Поставщик не удалён"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /supplier/{id}/restore [post]
+func (h *Handler) restoreSupplier(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return
+	}
+
+	supplier, err := h.services.RestoreSupplier(c, supplierID)
+	if err != nil {
+		newErrorResponse(c, err, "supplier.restore_failed")
+		return
+	}
+
+	setETag(c, supplier.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"supplier": mapper.ToSupplierResponse(supplier),
+	})
 }
 
 // @Summary Получить список поставщиков
-// @Description Возвращает список всех поставщиков
+// @Description Возвращает страницу поставщиков в порядке названия и курсор следующей страницы
 // @Tags suppliers
 // @Accept json
-// @Produce json
-// @Success 200 {object} map[string][]response.SupplierResponse "Список поставщиков"
-// @Failure 404 {object} map[string]string "Ошибка при получении списка"
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param limit query int false "Количество поставщиков на странице, от 1 до 100 (по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Param include_deleted query boolean false "Включить удалённых поставщиков (только для администратора)"
+// @Success 200 {object} response.SupplierListResponse "Страница поставщиков"
+// @Failure 400 {object} response.Problem "Ошибка в параметрах запроса"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /supplier/supplierList [get]
 func (h *Handler) getSupplierList(c *gin.Context) {
-	supliers, err := h.services.GetSuppliersList(c)
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(c)
+	if !ok {
+		return
+	}
+
+	supliers, next, err := h.services.GetSuppliersList(c, page, includeDeleted)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "supplier.list_failed")
 		return
 	}
 
@@ -126,8 +211,70 @@ func (h *Handler) getSupplierList(c *gin.Context) {
 		suplierResponses[i] = mapper.ToSupplierResponse(suplier)
 	}
 
+	c.JSON(http.StatusOK, response.SupplierListResponse{
+		Suppliers: suplierResponses,
+		Page:      pageResponse(next),
+	})
+}
+
+// @Summary Изменить поставщика
+// @Description Частично изменяет поставщика: тело в формате JSON Merge Patch содержит только изменяемые поля
+// @Description (name, phone_number). Адрес меняется отдельным запросом.
+// @Description If-Match должен содержать ETag, полученный при чтении поставщика.
+// @Tags suppliers
+// @Accept application/merge-patch+json,json
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID поставщика"
+// @Param If-Match header string true "ETag поставщика"
+// @Param patch body response.UpdateSupplier true "Изменяемые поля поставщика"
+// @Success 200 {object} map[string]response.SupplierResponse "Изменённый поставщик"
+// @Header 200 {string} ETag "Новая версия поставщика"
+// @Failure 400 {object} response.Problem "Ошибка в данных"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Поставщик не найден"
+// @Failure 409 {object} response.Problem "Телефон уже используется"
+// @Failure 412 {object} response.Problem "Поставщик изменён после чтения"
+// @Failure 428 {object} response.Problem "Не передан заголовок If-Match"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /supplier/{id} [patch]
+func (h *Handler) patchSupplier(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	supplier, err := h.services.GetSupplierByID(c, supplierID)
+	if err != nil {
+		newErrorResponse(c, err, "supplier.get_failed")
+		return
+	}
+
+	var supplierReq response.UpdateSupplier
+	if !bindMergePatch(c, mapper.ToUpdateSupplier(supplier), &supplierReq) {
+		return
+	}
+
+	update := mapper.ToUpdatedSupplierModel(supplierReq)
+	update.ID = supplierID
+	update.Version = version
+
+	supplier, err = h.services.UpdateSupplier(c, update)
+	if err != nil {
+		newErrorResponse(c, err, "supplier.update_failed")
+		return
+	}
+
+	setETag(c, supplier.Version)
 	c.JSON(http.StatusOK, gin.H{
-		"supliers": suplierResponses,
+		"supplier": mapper.ToSupplierResponse(supplier),
 	})
 }
 
@@ -135,34 +282,74 @@ func (h *Handler) getSupplierList(c *gin.Context) {
 // @Description Возвращает данные поставщика по его ID
 // @Tags suppliers
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param id path string true "UUID поставщика"
 // @Success 200 {object} response.SupplierResponse "Данные поставщика"
-// @Failure 400 {object} map[string]string "Некорректный UUID или отсутствует ID"
-// @Failure 404 {object} map[string]string "Ошибка при получении поставщика"
+// @Header 200 {string} ETag "Версия поставщика для заголовка If-Match"
+// @Failure 400 {object} response.Problem "Некорректный UUID или отсутствует ID"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Ошибка при получении поставщика"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /supplier/{id} [get]
 func (h *Handler) getSupplier(c *gin.Context) {
 	supplierIDStr := c.Param("id")
 	if supplierIDStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID поставщика обязателен"})
+		newValidationErrorResponse(c, "id", "request.supplier_required")
 		return
 	}
 
 	supplierID, err := uuid.Parse(supplierIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный формат ID"})
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
 		return
 	}
 
 	supplier, err := h.services.GetSupplierByID(c, supplierID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении поставщика: %s", err.Error())})
+		newErrorResponse(c, err, "supplier.get_failed")
 		return
 	}
 
 	supplierResponse := mapper.ToSupplierResponse(supplier)
 
+	setETag(c, supplier.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"supplier": supplierResponse,
 	})
 }
+
+// @Summary Получить адрес поставщика
+// @Description Возвращает адрес поставщика по его ID. ETag ответа передаётся в If-Match
+// @Description при изменении адреса.
+// @Tags suppliers
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID поставщика"
+// @Success 200 {object} map[string]response.AddressResponse "Адрес поставщика"
+// @Header 200 {string} ETag "Версия адреса для заголовка If-Match"
+// @Failure 400 {object} response.Problem "Некорректный UUID поставщика"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Поставщик не найден"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /supplier/{id}/address [get]
+func (h *Handler) getSupplierAddress(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.supplier_uuid")
+		return
+	}
+
+	address, err := h.services.GetSupplierAddress(c, supplierID)
+	if err != nil {
+		newErrorResponse(c, err, "supplier.address_get_failed")
+		return
+	}
+
+	setETag(c, address.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"address": mapper.ToAddressResponse(address),
+	})
+}
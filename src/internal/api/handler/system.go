@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"src/internal/middleware/mapper"
+)
+
+// @Summary      Статистика пула соединений
+// @Description  Возвращает текущую статистику пула соединений с базой данных
+// @Tags         system
+// @Produce      json,application/problem+json
+// @Security     BearerAuth
+// @Success      200  {object}  response.PoolStatsResponse
+// @Failure      401  {object}  response.Problem  "Требуется аутентификация"
+// @Failure      403  {object}  response.Problem  "Недостаточно прав"
+// @Router       /system/dbPool [get]
+func (h *Handler) getPoolStats(c *gin.Context) {
+	stats := h.services.GetPoolStats()
+
+	c.JSON(http.StatusOK, mapper.ToPoolStatsResponse(stats))
+}
@@ -1,94 +1,159 @@
 package handler
 
 import (
-	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
 	"src/internal/api/response"
 	"src/internal/middleware/mapper"
-	"strconv"
+	"src/internal/repository/model"
+	"time"
 )
 
 // @Summary Создание пользователя
 // @Description Создает нового пользователя на основе переданных данных
 // @Tags users
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param user body response.CreateUser true "Данные нового пользователя"
 // @Success 201 {object} map[string]string "Пользователь успешно создан"
-// @Failure 400 {object} map[string]string "Ошибка в данных"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
+// @Failure 400 {object} response.Problem "Ошибка в данных"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 500 {object} response.Problem "Ошибка сервера"
 // @Router /user/create [post]
 func (h *Handler) createUser(c *gin.Context) {
 	var userReq response.CreateUser
 
 	if err := c.ShouldBindJSON(&userReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
 	address := mapper.ToAddressModel(userReq.Address)
-	user := mapper.ToUserModel(userReq)
+	user, err := mapper.ToUserModel(userReq)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
 
 	id, err := h.services.AddUser(c, user, address)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Не удалось создать пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "user.create_failed")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Пользователь успешно создан",
+		"message": t(c, "user.created"),
 		"id":      id.String(),
 	})
 }
 
 // @Summary Удаление пользователя
-// @Description Удаляет пользователя по UUID
+// @Description Помечает пользователя удалённым, если он не менялся с версии из If-Match.
+// @Description Пользователя можно восстановить до окончательной очистки по истечении срока хранения.
 // @Tags users
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param id path string true "UUID пользователя"
+// @Param If-Match header string true "ETag пользователя"
 // @Success 200 {object} map[string]string "Пользователь успешно удалён"
-// @Failure 400 {object} map[string]string "Неверный формат UUID"
-// @Failure 404 {object} map[string]string "Ошибка при удалении пользователя"
+// @Failure 400 {object} response.Problem "Неверный формат UUID"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Ошибка при удалении пользователя"
+// @Failure 409 {object} response.Problem "Конфликт с текущим состоянием данных"
+// @Failure 412 {object} response.Problem "Пользователь изменён после чтения"
+// @Failure 428 {object} response.Problem "Не передан заголовок If-Match"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /user/delete/{id} [delete]
 func (h *Handler) deleteUser(c *gin.Context) {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID пользователя"})
+		newValidationErrorResponse(c, "id", "request.user_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
-	err = h.services.RemoveUser(c, userID)
+	err = h.services.RemoveUser(c, userID, version)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при удалении пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "user.delete_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Пользователь успешно удалён"})
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "user.deleted")})
+}
+
+// @Summary Восстановление пользователя
+// @Description Снимает с пользователя пометку об удалении. Восстановить можно только пользователя,
+// @Description ещё не удалённого окончательно по истечении срока хранения.
+// @Tags users
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID пользователя"
+// @Success 200 {object} map[string]response.UserResponse "Восстановленный пользователь"
+// @Failure 400 {object} response.Problem "Неверный формат UUID"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Пользователь не найден"
+// @Failure 409 {object} response.Problem "Пользователь не удалён"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /user/{id}/restore [post]
+func (h *Handler) restoreUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.user_uuid")
+		return
+	}
+
+	user, err := h.services.RestoreUser(c, userID)
+	if err != nil {
+		newErrorResponse(c, err, "user.restore_failed")
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"user": mapper.ToUserResponse(user),
+	})
 }
 
 // @Summary Получение списка пользователей по имени и фамилии
 // @Description Возвращает список пользователей, отфильтрованных по имени и фамилии
 // @Tags users
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param name query string true "Имя пользователя"
 // @Param surname query string true "Фамилия пользователя"
 // @Success 200 {object} map[string][]response.UserResponse "Список пользователей"
-// @Failure 400 {object} map[string]string "Ошибка в параметрах запроса"
-// @Failure 404 {object} map[string]string "Ошибка сервера"
+// @Failure 400 {object} response.Problem "Ошибка в параметрах запроса"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /user/users [get]
 func (h *Handler) getUsers(c *gin.Context) {
 	name := c.Query("name")
 	surname := c.Query("surname")
 
-	if name == "" || surname == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Имя и фамилия обязательны"})
+	if name == "" {
+		newValidationErrorResponse(c, "name", "request.name_required")
+		return
+	}
+
+	if surname == "" {
+		newValidationErrorResponse(c, "surname", "request.surname_required")
 		return
 	}
 
 	users, err := h.services.GetUsers(c, name, surname)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "user.list_failed")
 		return
 	}
 
@@ -100,41 +165,168 @@ func (h *Handler) getUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"users": userResponses})
 }
 
-// @Summary Получение списка пользователей с пагинацией
-// @Description Возвращает список пользователей с возможностью пагинации
+// @Summary Получить клиента
+// @Description Возвращает личные данные клиента по UUID
 // @Tags users
-// @Param limit query int false "Количество пользователей на странице (по умолчанию 20)"
-// @Param offset query int false "Смещение (по умолчанию 0)"
-// @Success 200 {object} map[string]interface{} "Список пользователей и флаг has_more"
-// @Failure 400 {object} map[string]string "Ошибка в параметрах запроса"
-// @Failure 404 {object} map[string]string "Ошибка сервера"
-// @Router /user/usersList [get]
-func (h *Handler) getUserList(c *gin.Context) {
-	limitStr := c.Query("limit")
-	offsetStr := c.Query("offset")
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID клиента"
+// @Success 200 {object} map[string]response.UserResponse "Данные клиента"
+// @Header 200 {string} ETag "Версия клиента для заголовка If-Match"
+// @Failure 400 {object} response.Problem "Неверный формат UUID"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Клиент не найден"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /user/{id} [get]
+func (h *Handler) getUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.user_uuid")
+		return
+	}
 
-	limit, offset := 20, 0
-	var err error
+	user, err := h.services.GetUserByID(c, userID)
+	if err != nil {
+		newErrorResponse(c, err, "user.get_failed")
+		return
+	}
 
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil || limit < 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Параметр 'limit' должен быть целым числом >= 0"})
-			return
-		}
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"user": mapper.ToUserResponse(user),
+	})
+}
+
+// @Summary Получить адрес клиента
+// @Description Возвращает адрес клиента по UUID. ETag ответа передаётся в If-Match при изменении адреса.
+// @Tags users
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID клиента"
+// @Success 200 {object} map[string]response.AddressResponse "Адрес клиента"
+// @Header 200 {string} ETag "Версия адреса для заголовка If-Match"
+// @Failure 400 {object} response.Problem "Неверный формат UUID"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Клиент не найден"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /user/{id}/address [get]
+func (h *Handler) getUserAddress(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.user_uuid")
+		return
 	}
 
-	if offsetStr != "" {
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Параметр 'offset' должен быть целым числом >= 0"})
-			return
-		}
+	address, err := h.services.GetUserAddress(c, userID)
+	if err != nil {
+		newErrorResponse(c, err, "user.address_get_failed")
+		return
 	}
 
-	users, err := h.services.GetUsersList(c, limit, offset)
+	setETag(c, address.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"address": mapper.ToAddressResponse(address),
+	})
+}
+
+// @Summary Изменить клиента
+// @Description Частично изменяет личные данные клиента: тело в формате JSON Merge Patch содержит
+// @Description только изменяемые поля (name, surname, birthday, gender). Адрес меняется отдельным запросом.
+// @Description If-Match должен содержать ETag, полученный при чтении клиента.
+// @Tags users
+// @Accept application/merge-patch+json,json
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param id path string true "UUID клиента"
+// @Param If-Match header string true "ETag клиента"
+// @Param patch body response.UpdateProfile true "Изменяемые поля клиента"
+// @Success 200 {object} map[string]response.UserResponse "Изменённый клиент"
+// @Header 200 {string} ETag "Новая версия клиента"
+// @Failure 400 {object} response.Problem "Ошибка в данных"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Клиент не найден"
+// @Failure 412 {object} response.Problem "Клиент изменён после чтения"
+// @Failure 428 {object} response.Problem "Не передан заголовок If-Match"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /user/{id} [patch]
+func (h *Handler) patchUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		newValidationErrorResponse(c, "id", "request.user_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.services.GetUserByID(c, userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при получении пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "user.get_failed")
+		return
+	}
+
+	var userReq response.UpdateProfile
+	if !bindMergePatch(c, mapper.ToUpdateProfile(user), &userReq) {
+		return
+	}
+
+	update, err := mapper.ToProfileModel(userReq)
+	if err != nil {
+		newErrorResponse(c, err, "request.invalid_data")
+		return
+	}
+	update.ID = userID
+	update.Version = version
+
+	user, err = h.services.UpdateUser(c, update)
+	if err != nil {
+		newErrorResponse(c, err, "user.update_failed")
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"user": mapper.ToUserResponse(user),
+	})
+}
+
+// @Summary Поиск клиентов
+// @Description Ищет клиентов по началу имени или фамилии без учёта регистра и по похожести (для опечаток).
+// @Description Результаты можно сузить по дате рождения, полу, дате регистрации, городу и стране.
+// @Description С запросом q результаты упорядочены по релевантности, без него — по дате регистрации.
+// @Tags users
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param q query string false "Имя, фамилия или их начало"
+// @Param birthday_from query string false "Дата рождения не раньше (ГГГГ-ММ-ДД)"
+// @Param birthday_to query string false "Дата рождения не позже (ГГГГ-ММ-ДД)"
+// @Param gender query string false "Пол" Enums(Male, Female, Other)
+// @Param registered_from query string false "Дата регистрации не раньше (ГГГГ-ММ-ДД)"
+// @Param registered_to query string false "Дата регистрации не позже (ГГГГ-ММ-ДД)"
+// @Param city query string false "Город"
+// @Param country query string false "Страна"
+// @Param limit query int false "Количество клиентов на странице, от 1 до 100 (по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} response.UserListResponse "Страница найденных клиентов"
+// @Failure 400 {object} response.Problem "Ошибка в параметрах запроса"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /user/search [get]
+func (h *Handler) searchUsers(c *gin.Context) {
+	filter, ok := parseClientFilter(c)
+	if !ok {
+		return
+	}
+
+	users, next, err := h.services.SearchUsers(c, filter)
+	if err != nil {
+		newErrorResponse(c, err, "user.search_failed")
 		return
 	}
 
@@ -143,47 +335,143 @@ func (h *Handler) getUserList(c *gin.Context) {
 		userResponses[i] = mapper.ToUserResponse(user)
 	}
 
-	hasMore := len(users) == limit
+	c.JSON(http.StatusOK, response.UserListResponse{
+		Users: userResponses,
+		Page:  pageResponse(next),
+	})
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"users":    userResponses,
-		"has_more": hasMore,
+// @Summary Получение списка пользователей с пагинацией
+// @Description Возвращает страницу пользователей в порядке регистрации и курсор следующей страницы
+// @Tags users
+// @Produce json,application/problem+json
+// @Security BearerAuth
+// @Param limit query int false "Количество пользователей на странице, от 1 до 100 (по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Param include_deleted query boolean false "Включить удалённых пользователей (только для администратора)"
+// @Success 200 {object} response.UserListResponse "Страница пользователей"
+// @Failure 400 {object} response.Problem "Ошибка в параметрах запроса"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
+// @Router /user/usersList [get]
+func (h *Handler) getUserList(c *gin.Context) {
+	page, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	includeDeleted, ok := parseIncludeDeleted(c)
+	if !ok {
+		return
+	}
+
+	users, next, err := h.services.GetUsersList(c, page, includeDeleted)
+	if err != nil {
+		newErrorResponse(c, err, "user.list_failed")
+		return
+	}
+
+	userResponses := make([]response.UserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = mapper.ToUserResponse(user)
+	}
+
+	c.JSON(http.StatusOK, response.UserListResponse{
+		Users: userResponses,
+		Page:  pageResponse(next),
 	})
 }
 
 // @Summary Обновление адреса пользователя
-// @Description Изменяет адрес пользователя по UUID
+// @Description Изменяет адрес пользователя по UUID. If-Match должен содержать ETag адреса,
+// @Description полученный из GET /user/{id}/address.
 // @Tags users
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
+// @Security BearerAuth
 // @Param id path string true "UUID пользователя"
+// @Param If-Match header string true "ETag адреса"
 // @Param address body response.CreateUpdateAddress true "Новый адрес пользователя"
 // @Success 200 {object} map[string]string "Адрес успешно изменен"
-// @Failure 400 {object} map[string]string "Ошибка в параметрах запроса"
-// @Failure 404 {object} map[string]string "Ошибка сервера"
+// @Header 200 {string} ETag "Новая версия адреса"
+// @Failure 400 {object} response.Problem "Ошибка в параметрах запроса"
+// @Failure 401 {object} response.Problem "Требуется аутентификация"
+// @Failure 403 {object} response.Problem "Недостаточно прав"
+// @Failure 404 {object} response.Problem "Пользователь не найден"
+// @Failure 412 {object} response.Problem "Адрес изменён после чтения"
+// @Failure 428 {object} response.Problem "Не передан заголовок If-Match"
+// @Failure 500 {object} response.Problem "Внутренняя ошибка сервера"
 // @Router /user/updateAddress/{id} [put]
 func (h *Handler) updateUserAddress(c *gin.Context) {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат UUID пользователя"})
+		newValidationErrorResponse(c, "id", "request.user_uuid")
+		return
+	}
+
+	version, ok := parseIfMatch(c)
+	if !ok {
 		return
 	}
 
 	var Address response.CreateUpdateAddress
 
 	if err := c.ShouldBindJSON(&Address); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Ошибка при разборе данных: %s", err.Error())})
+		newBindErrorResponse(c, err)
 		return
 	}
 
 	address := mapper.ToAddressModel(Address)
+	address.Version = version
 
-	err = h.services.UpdateUserAddress(c, userID, address)
+	address, err = h.services.UpdateUserAddress(c, userID, address)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Ошибка при удалении пользователя: %s", err.Error())})
+		newErrorResponse(c, err, "user.address_update_failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Адрес успешно изменен"})
+	setETag(c, address.Version)
+	c.JSON(http.StatusOK, gin.H{"message": t(c, "user.address_updated")})
+}
+
+// parseClientFilter разбирает query-параметры поиска клиентов. Допустимость значений
+// и порядок границ диапазонов проверяет сервис.
+func parseClientFilter(c *gin.Context) (model.ClientFilter, bool) {
+	page, ok := parsePage(c)
+	if !ok {
+		return model.ClientFilter{}, false
+	}
+
+	filter := model.ClientFilter{
+		Query:   c.Query("q"),
+		Gender:  c.Query("gender"),
+		City:    c.Query("city"),
+		Country: c.Query("country"),
+		Page:    page,
+	}
+
+	for _, date := range []struct {
+		field  string
+		target **time.Time
+	}{
+		{"birthday_from", &filter.BirthdayFrom},
+		{"birthday_to", &filter.BirthdayTo},
+		{"registered_from", &filter.RegisteredFrom},
+		{"registered_to", &filter.RegisteredTo},
+	} {
+		value := c.Query(date.field)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			newValidationErrorResponse(c, date.field, "validation.iso_date")
+			return model.ClientFilter{}, false
+		}
+		*date.target = &parsed
+	}
+
+	return filter, true
 }
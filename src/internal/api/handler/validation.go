@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var registerValidatorsOnce sync.Once
+
+// registerValidators настраивает валидатор gin: имена полей в ошибках берутся из тега json,
+// добавляется правило iso_date для дат вида 2006-01-02.
+func registerValidators() {
+	registerValidatorsOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+
+		v.RegisterTagNameFunc(jsonFieldName)
+		if err := v.RegisterValidation("iso_date", isISODate); err != nil {
+			panic(err)
+		}
+	})
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func isISODate(fl validator.FieldLevel) bool {
+	_, err := time.Parse(time.DateOnly, fl.Field().String())
+	return err == nil
+}
+
+// fieldPath возвращает путь к полю без имени корневой структуры, например address.city или items[0].quantity.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// ruleKeys сопоставляет правила валидатора с сообщениями каталога.
+var ruleKeys = map[string]string{
+	"required": "validation.required",
+	"max":      "validation.max",
+	"min":      "validation.min",
+	"gt":       "validation.gt",
+	"gte":      "validation.gte",
+	"lte":      "validation.lte",
+	"ne":       "validation.ne",
+	"oneof":    "validation.oneof",
+	"uuid":     "validation.uuid",
+	"email":    "validation.email",
+	"iso_date": "validation.iso_date",
+}
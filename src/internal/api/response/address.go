@@ -1,9 +1,9 @@
 package response
 
 type CreateUpdateAddress struct {
-	Country string `json:"country"`
-	City    string `json:"city"`
-	Street  string `json:"street"`
+	Country string `json:"country" binding:"required,max=100"`
+	City    string `json:"city" binding:"required,max=100"`
+	Street  string `json:"street" binding:"required,max=255"`
 }
 
 type AddressResponse struct {
@@ -11,4 +11,5 @@ type AddressResponse struct {
 	Country string `json:"country"`
 	City    string `json:"city"`
 	Street  string `json:"street"`
+	Version int    `json:"version"`
 }
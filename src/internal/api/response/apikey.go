@@ -0,0 +1,41 @@
+package response
+
+import "time"
+
+type CreateAPIKey struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=products:read stock:write prices:write"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// RotateAPIKey — необязательное тело запроса ротации: срок действия нового ключа.
+type RotateAPIKey struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         string   `json:"id"`
+	SupplierID string   `json:"supplier_id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at,omitempty"`
+	RevokedAt  string   `json:"revoked_at,omitempty"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+	Page
+}
+
+// IssuedAPIKeyResponse содержит открытый ключ. Он возвращается один раз и нигде не хранится.
+type IssuedAPIKeyResponse struct {
+	Key    string         `json:"key"`
+	APIKey APIKeyResponse `json:"api_key"`
+}
+
+type UpdatePrice struct {
+	Price *float64 `json:"price" binding:"required,gte=0,lte=99999999.99"`
+}
@@ -0,0 +1,23 @@
+package response
+
+type Login struct {
+	Login    string `json:"login" binding:"required,max=100" example:"admin"`
+	Password string `json:"password" binding:"required,max=72" example:"secret-password"`
+}
+
+type RefreshToken struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"900"`
+}
+
+type CreateStaffUser struct {
+	Login    string `json:"login" binding:"required,max=100" example:"warehouse-1"`
+	Password string `json:"password" binding:"required,max=72" example:"secret-password"`
+	Role     string `json:"role" binding:"required,oneof=admin catalog_manager warehouse read_only" example:"warehouse"`
+}
@@ -0,0 +1,43 @@
+package response
+
+type RegisterClient struct {
+	Email    string     `json:"email" binding:"required,email,max=255" example:"client@example.com"`
+	Password string     `json:"password" binding:"required,max=72" example:"secret-password"`
+	Profile  CreateUser `json:"profile"`
+}
+
+type ClientLogin struct {
+	Email    string `json:"email" binding:"required,email,max=255" example:"client@example.com"`
+	Password string `json:"password" binding:"required,max=72" example:"secret-password"`
+}
+
+type ClientToken struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type RequestPasswordReset struct {
+	Email string `json:"email" binding:"required,email,max=255" example:"client@example.com"`
+}
+
+type ResetPassword struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,max=72" example:"new-secret-password"`
+}
+
+type UpdateProfile struct {
+	ClientName    string `json:"name" binding:"required,max=100"`
+	ClientSurname string `json:"surname" binding:"required,max=100"`
+	Birthday      string `json:"birthday" binding:"required,iso_date" example:"1990-05-17"`
+	Gender        string `json:"gender" binding:"required,oneof=Male Female Other"`
+}
+
+type ProfileResponse struct {
+	ID            string          `json:"id"`
+	ClientName    string          `json:"name"`
+	ClientSurname string          `json:"surname"`
+	Birthday      string          `json:"birthday"`
+	Gender        string          `json:"gender"`
+	Registration  string          `json:"registration_date"`
+	Version       int             `json:"version"`
+	Address       AddressResponse `json:"address"`
+}
@@ -1,8 +1,8 @@
 package response
 
 type UploadUpdateImage struct {
-	ID        string `json:"product_id"`
-	ImageData []byte `json:"image"`
+	ID        string `json:"product_id" binding:"required,uuid"`
+	ImageData []byte `json:"image" binding:"required"`
 }
 
 type ImageResponse struct {
@@ -0,0 +1,37 @@
+package response
+
+type CheckoutItem struct {
+	ProductID string `json:"product_id" binding:"required,uuid"`
+	Quantity  int    `json:"quantity" binding:"gt=0,lte=2147483647"`
+}
+
+type Checkout struct {
+	ClientID string         `json:"client_id" binding:"required,uuid"`
+	Items    []CheckoutItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type UpdateOrderStatus struct {
+	Status string `json:"status" binding:"required,oneof=created paid shipped cancelled"`
+}
+
+type OrderItemResponse struct {
+	ID        string  `json:"id"`
+	ProductID string  `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+type OrderListResponse struct {
+	Orders []OrderResponse `json:"orders"`
+	Page
+}
+
+type OrderResponse struct {
+	ID        string              `json:"id"`
+	ClientID  string              `json:"client_id"`
+	Status    string              `json:"status"`
+	Total     float64             `json:"total"`
+	CreatedAt string              `json:"created_at"`
+	UpdatedAt string              `json:"updated_at"`
+	Items     []OrderItemResponse `json:"items,omitempty"`
+}
@@ -0,0 +1,8 @@
+package response
+
+// Page встраивается в ответы списков. Чтобы получить следующую страницу, next_cursor
+// передаётся в параметре cursor вместе с теми же фильтрами и сортировкой.
+type Page struct {
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+}
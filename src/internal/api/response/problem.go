@@ -0,0 +1,37 @@
+package response
+
+// ProblemContentType — тип содержимого ответа с ошибкой (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Стабильные коды ошибок, на которые может опираться клиент.
+const (
+	CodeMalformedBody        = "malformed_body"
+	CodeValidationFailed     = "validation_failed"
+	CodeNotFound             = "not_found"
+	CodeRouteNotFound        = "route_not_found"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeConflict             = "conflict"
+	CodeDuplicatePhone       = "duplicate_phone_number"
+	CodePreconditionFailed   = "precondition_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeInsufficientStock    = "insufficient_stock"
+	CodeInternal             = "internal_error"
+)
+
+// Problem — тело ответа с ошибкой в формате application/problem+json.
+type Problem struct {
+	Type     string       `json:"type" example:"/problems/not_found"`
+	Title    string       `json:"title" example:"Объект не найден"`
+	Status   int          `json:"status" example:"404"`
+	Detail   string       `json:"detail,omitempty" example:"Ошибка при получении товара: объект не найден"`
+	Instance string       `json:"instance,omitempty" example:"/api/v1/product/0b7c2d1e-6f0a-4c53-9a43-8a3f1f9d2b10"`
+	Code     string       `json:"code" example:"not_found"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError описывает ошибку в конкретном поле запроса.
+type FieldError struct {
+	Field   string `json:"field" example:"quantity"`
+	Message string `json:"message" example:"должно быть положительным числом"`
+}
@@ -1,11 +1,11 @@
 package response
 
 type CreateProduct struct {
-	Name           string  `json:"name"`
-	Category       string  `json:"category"`
-	Price          float64 `json:"price"`
-	AvailableStock int     `json:"available_stock"`
-	SupplierID     string  `json:"supplierID"`
+	Name           string  `json:"name" binding:"required,max=255"`
+	Category       string  `json:"category" binding:"required,max=100"`
+	Price          float64 `json:"price" binding:"gte=0,lte=99999999.99"`
+	AvailableStock int     `json:"available_stock" binding:"gte=0,lte=2147483647"`
+	SupplierID     string  `json:"supplierID" binding:"required,uuid"`
 }
 
 type ProductResponse struct {
@@ -14,7 +14,43 @@ type ProductResponse struct {
 	Category       string  `json:"category"`
 	Price          float64 `json:"price"`
 	AvailableStock int     `json:"available_stock"`
+	ReservedStock  int     `json:"reserved_stock"`
 	LastUpdateDate string  `json:"lastUpdateDate"`
 	SupplierID     string  `json:"supplierID"`
 	ImageID        string  `json:"imageID"`
+	Version        int     `json:"version"`
+	DeletedAt      string  `json:"deleted_at,omitempty"`
+}
+
+type ProductListResponse struct {
+	Products []ProductResponse `json:"products"`
+	Total    int               `json:"total"`
+	Page
+}
+
+// ProductHighlight содержит название и категорию в виде HTML: текст экранирован,
+// а совпавшие с запросом слова обёрнуты в <mark>...</mark>.
+type ProductHighlight struct {
+	Name     string `json:"name" example:"<mark>Молоко</mark> пастеризованное"`
+	Category string `json:"category" example:"Молочные продукты"`
+}
+
+type ProductMatchResponse struct {
+	Product   ProductResponse  `json:"product"`
+	Rank      float64          `json:"rank"`
+	Highlight ProductHighlight `json:"highlight"`
+}
+
+type ProductSearchResponse struct {
+	Results []ProductMatchResponse `json:"results"`
+	Page
+}
+
+// UpdateProduct — изменяемые поля товара. PATCH принимает их подмножество в формате
+// JSON Merge Patch; остатки меняются только через операции склада.
+type UpdateProduct struct {
+	Name       string   `json:"name" binding:"required,max=255"`
+	Category   string   `json:"category" binding:"required,max=100"`
+	Price      *float64 `json:"price" binding:"required,gte=0,lte=99999999.99"`
+	SupplierID string   `json:"supplierID" binding:"required,uuid"`
 }
@@ -0,0 +1,16 @@
+package response
+
+type CreateReservation struct {
+	ProductID  string `json:"product_id" binding:"required,uuid"`
+	Quantity   int    `json:"quantity" binding:"gt=0,lte=2147483647"`
+	TTLSeconds int    `json:"ttl_seconds" binding:"gte=0"`
+}
+
+type ReservationResponse struct {
+	ID        string `json:"id"`
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package response
+
+type Restock struct {
+	Quantity int `json:"quantity" binding:"gt=0,lte=2147483647"`
+}
+
+type AdjustStock struct {
+	Delta int `json:"delta" binding:"ne=0,gte=-2147483647,lte=2147483647"`
+}
+
+type StockResponse struct {
+	ProductID      string `json:"product_id"`
+	AvailableStock int    `json:"available_stock"`
+}
+
+type StockMovementResponse struct {
+	ID          string `json:"id"`
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Delta       int    `json:"delta"`
+	Reason      string `json:"reason"`
+	Actor       string `json:"actor"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type StockMovementListResponse struct {
+	Movements []StockMovementResponse `json:"movements"`
+	Page
+}
@@ -1,14 +1,28 @@
 package response
 
 type CreateSupplier struct {
-	Name    string              `json:"name"`
+	Name    string              `json:"name" binding:"required,max=255"`
 	Address CreateUpdateAddress `json:"address"`
-	Phone   string              `json:"phone_number"`
+	Phone   string              `json:"phone_number" binding:"required,max=15"`
 }
 
 type SupplierResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Address string `json:"address"`
-	Phone   string `json:"phone_number"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+	Phone     string `json:"phone_number"`
+	Version   int    `json:"version"`
+	DeletedAt string `json:"deleted_at,omitempty"`
+}
+
+type SupplierListResponse struct {
+	Suppliers []SupplierResponse `json:"supliers"`
+	Page
+}
+
+// UpdateSupplier — изменяемые поля поставщика. PATCH принимает их подмножество в формате
+// JSON Merge Patch; адрес меняется отдельным запросом.
+type UpdateSupplier struct {
+	Name  string `json:"name" binding:"required,max=255"`
+	Phone string `json:"phone_number" binding:"required,max=15"`
 }
@@ -0,0 +1,40 @@
+package response
+
+type PoolStatsResponse struct {
+	MaxConns                int32  `json:"max_conns"`
+	TotalConns              int32  `json:"total_conns"`
+	AcquiredConns           int32  `json:"acquired_conns"`
+	IdleConns               int32  `json:"idle_conns"`
+	ConstructingConns       int32  `json:"constructing_conns"`
+	AcquireCount            int64  `json:"acquire_count"`
+	AcquireDuration         string `json:"acquire_duration"`
+	EmptyAcquireCount       int64  `json:"empty_acquire_count"`
+	CanceledAcquireCount    int64  `json:"canceled_acquire_count"`
+	NewConnsCount           int64  `json:"new_conns_count"`
+	MaxLifetimeDestroyCount int64  `json:"max_lifetime_destroy_count"`
+	MaxIdleDestroyCount     int64  `json:"max_idle_destroy_count"`
+}
+
+// Значения поля status в ответах проб /healthz и /readyz.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+)
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status string                        `json:"status"`
+	Checks map[string]DependencyResponse `json:"checks,omitempty"`
+}
+
+type DependencyResponse struct {
+	Status          string  `json:"status"`
+	LatencyMs       float64 `json:"latency_ms"`
+	Error           string  `json:"error,omitempty"`
+	Version         *int64  `json:"version,omitempty"`
+	ExpectedVersion *int64  `json:"expected_version,omitempty"`
+}
@@ -1,10 +1,10 @@
 package response
 
 type CreateUser struct {
-	ClientName    string              `json:"name"`
-	ClientSurname string              `json:"surname"`
-	Birthday      string              `json:"birthday"`
-	Gender        string              `json:"gender"`
+	ClientName    string              `json:"name" binding:"required,max=100"`
+	ClientSurname string              `json:"surname" binding:"required,max=100"`
+	Birthday      string              `json:"birthday" binding:"required,iso_date" example:"1990-05-17"`
+	Gender        string              `json:"gender" binding:"required,oneof=Male Female Other"`
 	Address       CreateUpdateAddress `json:"address"`
 }
 
@@ -16,4 +16,11 @@ type UserResponse struct {
 	Gender        string `json:"gender"`
 	Registration  string `json:"registration_date"`
 	Address       string `json:"address"`
+	Version       int    `json:"version"`
+	DeletedAt     string `json:"deleted_at,omitempty"`
+}
+
+type UserListResponse struct {
+	Users []UserResponse `json:"users"`
+	Page
 }
@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Config struct {
@@ -15,26 +17,56 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+
+	// Tracer получает события выполнения запросов всех соединений пула.
+	Tracer pgx.QueryTracer
 }
 
-func NewPostgresDB(cfg Config) (*pgx.Conn, error) {
+func NewPostgresDB(cfg Config) (*pgxpool.Pool, error) {
 	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.DBName, cfg.SSLMode)
 
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse database config: %w", err)
+	}
+
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+
+	poolConfig.ConnConfig.Tracer = cfg.Tracer
+
 	ctx := context.Background()
-	conn, err := pgx.Connect(ctx, dsn)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
-		log.Printf("Unable to connect to database: %v\n", err)
-		return nil, err
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
 
-	err = conn.Ping(ctx)
+	err = pool.Ping(ctx)
 	if err != nil {
-		conn.Close(ctx)
-		log.Printf("Database ping failed: %v\n", err)
-		return nil, err
+		pool.Close()
+		return nil, fmt.Errorf("database ping failed: %w", err)
 	}
 
-	log.Println("Successfully connected to the database!")
-	return conn, nil
+	slog.Info("connected to database", "host", cfg.Host, "port", cfg.Port, "database", cfg.DBName)
+	return pool, nil
 }
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"src/internal/i18n"
+)
+
+var (
+	ErrNotFound          = errors.New("объект не найден")
+	ErrConflict          = errors.New("конфликт с текущим состоянием данных")
+	ErrValidation        = errors.New("некорректные данные")
+	ErrInsufficientStock = errors.New("недостаточно товара на складе")
+	ErrUnauthorized      = errors.New("требуется аутентификация")
+	ErrForbidden         = errors.New("недостаточно прав")
+	ErrDuplicatePhone    = fmt.Errorf("%w: поставщик с таким номером телефона уже существует", ErrConflict)
+	ErrVersionMismatch   = fmt.Errorf("%w: объект изменён после того, как его прочитали", ErrConflict)
+)
+
+// kindKeys задаёт ключи каталога сообщений для видов ошибок.
+// Более конкретные виды идут раньше общих.
+var kindKeys = []struct {
+	kind error
+	key  string
+}{
+	{ErrDuplicatePhone, "error.duplicate_phone"},
+	{ErrVersionMismatch, "error.version_mismatch"},
+	{ErrInsufficientStock, "error.insufficient_stock"},
+	{ErrNotFound, "error.not_found"},
+	{ErrConflict, "error.conflict"},
+	{ErrValidation, "error.validation"},
+	{ErrUnauthorized, "error.unauthorized"},
+	{ErrForbidden, "error.forbidden"},
+}
+
+// Error связывает вид ошибки (одну из Err*) с сообщением для клиента и исходной причиной,
+// которая клиенту не показывается. Сообщение хранится как ключ каталога i18n и аргументы,
+// чтобы его можно было перевести на язык запроса.
+type Error struct {
+	Kind   error
+	Key    string
+	Args   []any
+	Err    error
+	Fields []FieldError
+}
+
+// FieldError описывает нарушение в конкретном поле запроса.
+type FieldError struct {
+	Field string
+	Key   string
+	Args  []any
+}
+
+func New(kind error, key string, args ...any) error {
+	return &Error{Kind: kind, Key: key, Args: args}
+}
+
+func Wrap(kind error, err error, key string, args ...any) error {
+	return &Error{Kind: kind, Key: key, Args: args, Err: err}
+}
+
+// Invalid возвращает ошибку проверки одного поля запроса.
+func Invalid(field, key string, args ...any) error {
+	return &Error{
+		Kind:   ErrValidation,
+		Key:    key,
+		Args:   args,
+		Fields: []FieldError{{Field: field, Key: key, Args: args}},
+	}
+}
+
+func (e *Error) Error() string {
+	message := i18n.Translate(i18n.Fallback, e.Key, e.Args...)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// Localize возвращает описание ошибки на языке lang без внутренних подробностей
+// (текста SQL, драйвера и т.п.). Для ошибок, не относящихся к предметной области,
+// возвращается пустая строка.
+func Localize(err error, lang string) string {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return i18n.Translate(lang, domainErr.Key, domainErr.Args...)
+	}
+
+	for _, k := range kindKeys {
+		if errors.Is(err, k.kind) {
+			return i18n.Translate(lang, k.key)
+		}
+	}
+
+	return ""
+}
+
+// Fields возвращает нарушения в полях запроса, если они есть в цепочке ошибок.
+func Fields(err error) []FieldError {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Fields
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package i18n
+
+var en = map[string]string{
+	// Заголовки ответов с ошибкой (поле title)
+	"problem.malformed_body":         "Malformed request body",
+	"problem.validation_failed":      "Invalid data",
+	"problem.not_found":              "Not found",
+	"problem.route_not_found":        "Route not found",
+	"problem.conflict":               "Conflict with the current state",
+	"problem.duplicate_phone_number": "Phone number already in use",
+	"problem.precondition_failed":    "Resource was modified by another request",
+	"problem.precondition_required":  "If-Match header is required",
+	"problem.insufficient_stock":     "Insufficient stock",
+	"problem.unauthorized":           "Authentication required",
+	"problem.forbidden":              "Forbidden",
+	"problem.internal_error":         "Internal server error",
+
+	// Ошибки разбора и проверки запроса
+	"request.malformed_body":            "Failed to parse request body",
+	"request.invalid_data":              "Invalid data",
+	"request.rule_violated":             "violates rule %s",
+	"request.invalid_type":              "invalid value type: %s expected",
+	"request.invalid_uuid":              "Invalid UUID format",
+	"request.name_required":             "Name is required",
+	"request.surname_required":          "Surname is required",
+	"request.limit_invalid":             "Parameter 'limit' must be an integer",
+	"request.limit_range":               "Parameter 'limit' must be an integer from %d to %d",
+	"request.cursor_invalid":            "Parameter 'cursor' is malformed",
+	"request.cursor_mismatch":           "cursor was issued for a different list or sort order",
+	"request.price_invalid":             "Parameter '%s' must be a non-negative number",
+	"request.bool_invalid":              "Parameter '%s' must be true or false",
+	"request.include_deleted_forbidden": "Deleted records are available to administrators only",
+	"request.datetime_invalid":          "Parameter '%s' must be a date-time in RFC 3339 format",
+	"request.date_range_invalid":        "date range start cannot be after its end",
+	"request.if_match_required":         "Changes require an If-Match header with the ETag received when reading the resource",
+	"request.if_match_invalid":          "If-Match header must contain the ETag received when reading the resource",
+	"request.field_not_patchable":       "field cannot be changed",
+	"request.quantity_positive":         "Quantity must be a positive number",
+	"request.product_uuid":              "Invalid product UUID format",
+	"request.image_uuid":                "Invalid image UUID format",
+	"request.user_uuid":                 "Invalid user UUID format",
+	"request.client_uuid":               "Invalid client UUID format",
+	"request.supplier_uuid":             "Invalid supplier UUID format",
+	"request.api_key_uuid":              "Invalid API key UUID format",
+	"request.order_uuid":                "Invalid order UUID format",
+	"request.reservation_uuid":          "Invalid reservation UUID format",
+	"request.product_id_required":       "Product ID is required",
+	"request.supplier_required":         "Supplier ID is required",
+
+	// Нарушения правил проверки полей
+	"validation.required": "field is required",
+	"validation.max":      "length must not exceed %s characters",
+	"validation.min":      "must contain at least %s items",
+	"validation.gt":       "must be greater than %s",
+	"validation.gte":      "must be at least %s",
+	"validation.lte":      "must be at most %s",
+	"validation.ne":       "must not be equal to %s",
+	"validation.oneof":    "allowed values: %s",
+	"validation.uuid":     "must be a valid UUID",
+	"validation.iso_date": "date must be in YYYY-MM-DD format",
+	"validation.email":    "must be a valid email address",
+
+	// Аутентификация и сотрудники
+	"auth.token_required":        "An access token is required in the Authorization: Bearer header",
+	"auth.authentication_failed": "Authentication failed",
+	"auth.forbidden":             "You are not allowed to perform this request",
+	"auth.login_failed":          "Failed to log in",
+	"auth.refresh_failed":        "Failed to refresh tokens",
+	"auth.invalid_credentials":   "invalid login or password",
+	"auth.invalid_refresh_token": "refresh token is invalid or expired",
+	"auth.invalid_token":         "access token is invalid or expired",
+	"auth.unknown_role":          "unknown role: %s",
+	"auth.password_too_short":    "password must be at least %d characters long",
+	"staff.created":              "Staff user created successfully",
+	"staff.create_failed":        "Failed to create staff user",
+	"staff.duplicate_login":      "a staff user with this login already exists",
+
+	// Клиенты: регистрация, вход и профиль
+	"client.registered":           "Client registered, a verification email has been sent",
+	"client.register_failed":      "Failed to register client",
+	"client.email_verified":       "Email address verified",
+	"client.verify_failed":        "Failed to verify email address",
+	"client.reset_requested":      "If the address is registered, a password reset email has been sent to it",
+	"client.reset_request_failed": "Failed to send password reset email",
+	"client.reset_failed":         "Failed to reset password",
+	"client.password_changed":     "Password changed",
+	"client.invalid_credentials":  "invalid email or password",
+	"client.email_not_verified":   "email address is not verified",
+	"client.invalid_token":        "token is invalid, already used or expired",
+	"client.duplicate_email":      "a client with this email address is already registered",
+	"me.get_failed":               "Failed to get profile",
+	"me.update_failed":            "Failed to update profile",
+	"me.updated":                  "Profile updated",
+	"mail.verify_email_subject":   "Email address verification",
+	"mail.verify_email_body":      "To verify your email address, follow the link: %s\nThe link is valid for %s.",
+	"mail.reset_password_subject": "Password reset",
+	"mail.reset_password_body":    "To set a new password, follow the link: %s\nThe link is valid for %s. If you did not request a password reset, ignore this email.",
+
+	// Пользователи
+	"user.created":               "User created successfully",
+	"user.create_failed":         "Failed to create user",
+	"user.deleted":               "User deleted successfully",
+	"user.delete_failed":         "Failed to delete user",
+	"user.restore_failed":        "Failed to restore user",
+	"user.update_failed":         "Failed to update user",
+	"user.list_failed":           "Failed to get users",
+	"user.get_failed":            "Failed to get user",
+	"user.search_failed":         "Failed to search users",
+	"user.address_get_failed":    "Failed to get user address",
+	"user.address_updated":       "Address updated successfully",
+	"user.address_update_failed": "Failed to update user address",
+	"user.not_found":             "user not found",
+	"user.not_deleted":           "user is not deleted",
+
+	// Поставщики
+	"supplier.created":               "Supplier created successfully",
+	"supplier.create_failed":         "Failed to create supplier",
+	"supplier.deleted":               "Supplier deleted successfully",
+	"supplier.delete_failed":         "Failed to delete supplier",
+	"supplier.restore_failed":        "Failed to restore supplier",
+	"supplier.get_failed":            "Failed to get supplier",
+	"supplier.list_failed":           "Failed to get suppliers",
+	"supplier.update_failed":         "Failed to update supplier",
+	"supplier.address_get_failed":    "Failed to get supplier address",
+	"supplier.address_updated":       "Address updated successfully",
+	"supplier.address_update_failed": "Failed to update supplier address",
+	"supplier.not_found":             "supplier not found",
+	"supplier.not_deleted":           "supplier is not deleted",
+
+	"address.not_found": "address not found",
+
+	// API-ключи поставщиков
+	"api_key.create_failed":   "Failed to issue the API key",
+	"api_key.list_failed":     "Failed to get API keys",
+	"api_key.rotate_failed":   "Failed to rotate the API key",
+	"api_key.revoke_failed":   "Failed to revoke the API key",
+	"api_key.revoked_ok":      "API key revoked",
+	"api_key.required":        "An API key is required in the X-API-Key header",
+	"api_key.scope_missing":   "The API key lacks the %s scope",
+	"api_key.scopes_required": "at least one scope is required",
+	"api_key.unknown_scope":   "unknown API key scope: %s",
+	"api_key.expiry_in_past":  "key expiry must be in the future",
+	"api_key.revoked":         "API key is already revoked",
+	"api_key.expired":         "API key has expired; issue a new key",
+	"api_key.invalid":         "API key is invalid, revoked or expired",
+
+	// Товары и склад
+	"product.created":             "Product created successfully",
+	"product.create_failed":       "Failed to create product",
+	"product.deleted":             "Product deleted successfully",
+	"product.delete_failed":       "Failed to delete product",
+	"product.restore_failed":      "Failed to restore product",
+	"product.get_failed":          "Failed to get product",
+	"product.list_failed":         "Failed to get products",
+	"product.update_failed":       "Failed to update product",
+	"product.search_failed":       "Failed to search products",
+	"product.reduced":             "Product quantity reduced",
+	"product.reduce_failed":       "Failed to reduce product quantity",
+	"product.not_found":           "product not found",
+	"product.not_deleted":         "product is not deleted",
+	"product.price_updated":       "Product price updated",
+	"product.price_update_failed": "Failed to update the product price",
+	"product.price_negative":      "price cannot be negative",
+	"product.price_range_invalid": "minimum price cannot exceed maximum price",
+	"stock.change_failed":         "Failed to change stock",
+	"stock.movements_failed":      "Failed to get stock movement history",
+	"stock.restock_quantity":      "restock quantity must be a positive number",
+	"stock.adjust_zero":           "stock adjustment cannot be zero",
+	"stock.reduce_quantity":       "quantity to reduce must be a positive number",
+	"stock.release_conflict":      "failed to release reserved stock: invalid ID or quantity",
+
+	// Изображения
+	"image.created":       "Image created successfully",
+	"image.create_failed": "Failed to create image",
+	"image.updated":       "Image updated successfully",
+	"image.update_failed": "Failed to update image",
+	"image.deleted":       "Image deleted successfully",
+	"image.delete_failed": "Failed to delete image",
+	"image.get_failed":    "Failed to get image",
+	"image.not_found":     "image not found",
+
+	// Заказы
+	"order.checkout_failed":    "Failed to place order",
+	"order.get_failed":         "Failed to get order",
+	"order.list_failed":        "Failed to get orders",
+	"order.status_changed":     "Order status changed",
+	"order.status_failed":      "Failed to change order status",
+	"order.status_conflict":    "failed to change order status: status already changed or invalid ID",
+	"order.unknown_status":     "unknown order status: %s",
+	"order.invalid_transition": "invalid order status transition: %s -> %s",
+	"order.empty":              "order must contain at least one item",
+	"order.item_quantity":      "quantity of product %s must be a positive number",
+
+	// Резервы
+	"reservation.create_failed":   "Failed to reserve product",
+	"reservation.get_failed":      "Failed to get reservation",
+	"reservation.confirmed":       "Reservation confirmed",
+	"reservation.confirm_failed":  "Failed to confirm reservation",
+	"reservation.released":        "Reservation released",
+	"reservation.release_failed":  "Failed to release reservation",
+	"reservation.quantity":        "reservation quantity must be a positive number",
+	"reservation.ttl_exceeded":    "reservation TTL cannot exceed %s",
+	"reservation.expired":         "reservation has expired",
+	"reservation.not_active":      "reservation is already %s",
+	"reservation.status_conflict": "failed to change reservation status: status already changed or invalid ID",
+
+	// Пробы готовности
+	"health.check_failed":    "dependency check failed",
+	"health.schema_mismatch": "schema version %d does not match expected %d",
+
+	// Ошибки предметной области и базы данных
+	"error.not_found":          "not found",
+	"error.conflict":           "conflict with the current state",
+	"error.validation":         "invalid data",
+	"error.insufficient_stock": "insufficient stock",
+	"error.duplicate_phone":    "a supplier with this phone number already exists",
+	"error.version_mismatch":   "resource was modified after it was read; fetch the current version and retry",
+	"error.unauthorized":       "authentication required",
+	"error.forbidden":          "access denied",
+	"error.duplicate":          "a record with the same data already exists",
+	"error.referenced":         "the object is referenced by other records",
+	"error.reference_missing":  "the referenced object does not exist",
+	"error.constraint":         "data violates constraints",
+	"error.out_of_range":       "value is out of range",
+}
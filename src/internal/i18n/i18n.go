@@ -0,0 +1,74 @@
+package i18n
+
+import (
+	"fmt"
+	"golang.org/x/text/language"
+)
+
+const (
+	Russian = "ru"
+	English = "en"
+)
+
+// Fallback — язык, на котором написаны исходные сообщения. Используется для логов
+// и в случае, когда перевода на выбранный язык нет.
+const Fallback = Russian
+
+var catalogs = map[string]map[string]string{
+	Russian: ru,
+	English: en,
+}
+
+// Supported сообщает, есть ли каталог сообщений для языка.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Translate возвращает сообщение key на языке lang, подставляя args.
+// Если сообщения нет в каталоге, используется Fallback, а затем сам ключ.
+func Translate(lang, key string, args ...any) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		format, ok = catalogs[Fallback][key]
+	}
+	if !ok {
+		format = key
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Negotiate выбирает язык ответа по заголовку Accept-Language.
+// Если заголовок пуст или ни один язык не подходит, возвращается def.
+func Negotiate(acceptLanguage, def string) string {
+	if !Supported(def) {
+		def = Fallback
+	}
+
+	tags := []language.Tag{language.Make(def)}
+	for lang := range catalogs {
+		if lang != def {
+			tags = append(tags, language.Make(lang))
+		}
+	}
+
+	_, index, confidence := language.NewMatcher(tags).Match(parseAcceptLanguage(acceptLanguage)...)
+	if confidence == language.No {
+		return def
+	}
+
+	base, _ := tags[index].Base()
+	return base.String()
+}
+
+func parseAcceptLanguage(acceptLanguage string) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
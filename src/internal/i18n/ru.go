@@ -0,0 +1,225 @@
+package i18n
+
+var ru = map[string]string{
+	// Заголовки ответов с ошибкой (поле title)
+	"problem.malformed_body":         "Некорректное тело запроса",
+	"problem.validation_failed":      "Ошибка в данных",
+	"problem.not_found":              "Объект не найден",
+	"problem.route_not_found":        "Маршрут не найден",
+	"problem.conflict":               "Конфликт с текущим состоянием данных",
+	"problem.duplicate_phone_number": "Номер телефона уже используется",
+	"problem.precondition_failed":    "Объект изменён другим запросом",
+	"problem.precondition_required":  "Требуется заголовок If-Match",
+	"problem.insufficient_stock":     "Недостаточно товара на складе",
+	"problem.unauthorized":           "Требуется аутентификация",
+	"problem.forbidden":              "Недостаточно прав",
+	"problem.internal_error":         "Внутренняя ошибка сервера",
+
+	// Ошибки разбора и проверки запроса
+	"request.malformed_body":            "Ошибка при разборе данных",
+	"request.invalid_data":              "Ошибка в данных",
+	"request.rule_violated":             "не удовлетворяет правилу %s",
+	"request.invalid_type":              "неверный тип значения: ожидается %s",
+	"request.invalid_uuid":              "Неверный формат UUID",
+	"request.name_required":             "Имя обязательно",
+	"request.surname_required":          "Фамилия обязательна",
+	"request.limit_invalid":             "Параметр 'limit' должен быть целым числом",
+	"request.limit_range":               "Параметр 'limit' должен быть целым числом от %d до %d",
+	"request.cursor_invalid":            "Параметр 'cursor' повреждён",
+	"request.cursor_mismatch":           "курсор выдан для другого списка или порядка сортировки",
+	"request.price_invalid":             "Параметр '%s' должен быть неотрицательным числом",
+	"request.bool_invalid":              "Параметр '%s' должен быть true или false",
+	"request.include_deleted_forbidden": "Удалённые записи доступны только администратору",
+	"request.datetime_invalid":          "Параметр '%s' должен быть датой и временем в формате RFC 3339",
+	"request.date_range_invalid":        "начало диапазона дат не может быть позже его конца",
+	"request.if_match_required":         "Изменение требует заголовка If-Match с ETag, полученным при чтении объекта",
+	"request.if_match_invalid":          "Заголовок If-Match должен содержать ETag, полученный при чтении объекта",
+	"request.field_not_patchable":       "поле нельзя изменить",
+	"request.quantity_positive":         "Количество должно быть положительным числом",
+	"request.product_uuid":              "Неверный формат UUID товара",
+	"request.image_uuid":                "Неверный формат UUID изображения",
+	"request.user_uuid":                 "Неверный формат UUID пользователя",
+	"request.client_uuid":               "Неверный формат UUID клиента",
+	"request.supplier_uuid":             "Неверный формат UUID поставщика",
+	"request.api_key_uuid":              "Неверный формат UUID API-ключа",
+	"request.order_uuid":                "Неверный формат UUID заказа",
+	"request.reservation_uuid":          "Неверный формат UUID резерва",
+	"request.product_id_required":       "ID товара обязателен",
+	"request.supplier_required":         "ID поставщика обязателен",
+
+	// Нарушения правил проверки полей
+	"validation.required": "обязательное поле",
+	"validation.max":      "длина не должна превышать %s символов",
+	"validation.min":      "должно содержать не менее %s элементов",
+	"validation.gt":       "должно быть больше %s",
+	"validation.gte":      "должно быть не меньше %s",
+	"validation.lte":      "должно быть не больше %s",
+	"validation.ne":       "не должно быть равно %s",
+	"validation.oneof":    "допустимые значения: %s",
+	"validation.uuid":     "должно быть UUID",
+	"validation.iso_date": "дата должна быть в формате ГГГГ-ММ-ДД",
+	"validation.email":    "должно быть адресом электронной почты",
+
+	// Аутентификация и сотрудники
+	"auth.token_required":        "Требуется токен доступа в заголовке Authorization: Bearer",
+	"auth.authentication_failed": "Ошибка аутентификации",
+	"auth.forbidden":             "Недостаточно прав для выполнения запроса",
+	"auth.login_failed":          "Не удалось выполнить вход",
+	"auth.refresh_failed":        "Не удалось обновить токены",
+	"auth.invalid_credentials":   "неверный логин или пароль",
+	"auth.invalid_refresh_token": "токен обновления недействителен или истёк",
+	"auth.invalid_token":         "токен доступа недействителен или истёк",
+	"auth.unknown_role":          "неизвестная роль: %s",
+	"auth.password_too_short":    "пароль должен содержать не менее %d символов",
+	"staff.created":              "Сотрудник успешно создан",
+	"staff.create_failed":        "Не удалось создать сотрудника",
+	"staff.duplicate_login":      "сотрудник с таким логином уже существует",
+
+	// Клиенты: регистрация, вход и профиль
+	"client.registered":           "Клиент зарегистрирован, письмо для подтверждения адреса почты отправлено",
+	"client.register_failed":      "Не удалось зарегистрировать клиента",
+	"client.email_verified":       "Адрес почты подтверждён",
+	"client.verify_failed":        "Не удалось подтвердить адрес почты",
+	"client.reset_requested":      "Если адрес зарегистрирован, на него отправлено письмо для смены пароля",
+	"client.reset_request_failed": "Не удалось отправить письмо для смены пароля",
+	"client.reset_failed":         "Не удалось сменить пароль",
+	"client.password_changed":     "Пароль изменён",
+	"client.invalid_credentials":  "неверный адрес почты или пароль",
+	"client.email_not_verified":   "адрес почты не подтверждён",
+	"client.invalid_token":        "токен недействителен, уже использован или истёк",
+	"client.duplicate_email":      "клиент с таким адресом почты уже зарегистрирован",
+	"me.get_failed":               "Ошибка при получении профиля",
+	"me.update_failed":            "Не удалось изменить профиль",
+	"me.updated":                  "Профиль изменён",
+	"mail.verify_email_subject":   "Подтверждение адреса почты",
+	"mail.verify_email_body":      "Чтобы подтвердить адрес почты, перейдите по ссылке: %s\nСсылка действительна %s.",
+	"mail.reset_password_subject": "Смена пароля",
+	"mail.reset_password_body":    "Чтобы задать новый пароль, перейдите по ссылке: %s\nСсылка действительна %s. Если вы не запрашивали смену пароля, проигнорируйте это письмо.",
+
+	// Пользователи
+	"user.created":               "Пользователь успешно создан",
+	"user.create_failed":         "Не удалось создать пользователя",
+	"user.deleted":               "Пользователь успешно удалён",
+	"user.delete_failed":         "Ошибка при удалении пользователя",
+	"user.restore_failed":        "Ошибка при восстановлении пользователя",
+	"user.update_failed":         "Не удалось изменить пользователя",
+	"user.list_failed":           "Ошибка при получении пользователей",
+	"user.get_failed":            "Ошибка при получении пользователя",
+	"user.search_failed":         "Ошибка при поиске пользователей",
+	"user.address_get_failed":    "Ошибка при получении адреса пользователя",
+	"user.address_updated":       "Адрес успешно изменен",
+	"user.address_update_failed": "Ошибка при изменении адреса пользователя",
+	"user.not_found":             "пользователь не найден",
+	"user.not_deleted":           "пользователь не удалён",
+
+	// Поставщики
+	"supplier.created":               "Поставщик успешно создан",
+	"supplier.create_failed":         "Не удалось создать поставщика",
+	"supplier.deleted":               "Поставщик успешно удалён",
+	"supplier.delete_failed":         "Ошибка при удалении поставщика",
+	"supplier.restore_failed":        "Ошибка при восстановлении поставщика",
+	"supplier.get_failed":            "Ошибка при получении поставщика",
+	"supplier.list_failed":           "Ошибка при получении поставщиков",
+	"supplier.update_failed":         "Не удалось изменить поставщика",
+	"supplier.address_get_failed":    "Ошибка при получении адреса поставщика",
+	"supplier.address_updated":       "Адрес успешно изменен",
+	"supplier.address_update_failed": "Ошибка при изменении адреса поставщика",
+	"supplier.not_found":             "поставщик не найден",
+	"supplier.not_deleted":           "поставщик не удалён",
+
+	"address.not_found": "адрес не найден",
+
+	// API-ключи поставщиков
+	"api_key.create_failed":   "Не удалось выпустить API-ключ",
+	"api_key.list_failed":     "Ошибка при получении API-ключей",
+	"api_key.rotate_failed":   "Не удалось выполнить ротацию API-ключа",
+	"api_key.revoke_failed":   "Не удалось отозвать API-ключ",
+	"api_key.revoked_ok":      "API-ключ отозван",
+	"api_key.required":        "Требуется API-ключ в заголовке X-API-Key",
+	"api_key.scope_missing":   "У API-ключа нет права %s",
+	"api_key.scopes_required": "необходимо указать хотя бы одно право",
+	"api_key.unknown_scope":   "неизвестное право API-ключа: %s",
+	"api_key.expiry_in_past":  "срок действия ключа должен быть в будущем",
+	"api_key.revoked":         "API-ключ уже отозван",
+	"api_key.expired":         "срок действия API-ключа истёк; выпустите новый ключ",
+	"api_key.invalid":         "API-ключ недействителен, отозван или истёк",
+
+	// Товары и склад
+	"product.created":             "Товар успешно создан",
+	"product.create_failed":       "Не удалось создать товар",
+	"product.deleted":             "Товар успешно удалён",
+	"product.delete_failed":       "Ошибка при удалении товара",
+	"product.restore_failed":      "Ошибка при восстановлении товара",
+	"product.get_failed":          "Ошибка при получении товара",
+	"product.list_failed":         "Ошибка при получении товаров",
+	"product.update_failed":       "Не удалось изменить товар",
+	"product.search_failed":       "Ошибка при поиске товаров",
+	"product.reduced":             "Количество товара уменьшено",
+	"product.reduce_failed":       "Не удалось уменьшить количество товара",
+	"product.not_found":           "товар не найден",
+	"product.not_deleted":         "товар не удалён",
+	"product.price_updated":       "Цена товара изменена",
+	"product.price_update_failed": "Не удалось изменить цену товара",
+	"product.price_negative":      "цена не может быть отрицательной",
+	"product.price_range_invalid": "минимальная цена не может превышать максимальную",
+	"stock.change_failed":         "Ошибка при изменении остатка",
+	"stock.movements_failed":      "Ошибка при получении истории движения",
+	"stock.restock_quantity":      "количество поступления должно быть положительным числом",
+	"stock.adjust_zero":           "корректировка остатка не может быть нулевой",
+	"stock.reduce_quantity":       "количество списания должно быть положительным числом",
+	"stock.release_conflict":      "не удалось снять резерв товара: неверный ID или количество",
+
+	// Изображения
+	"image.created":       "Изображение успешно создано",
+	"image.create_failed": "Не удалось создать изображение",
+	"image.updated":       "Изображение успешно изменено",
+	"image.update_failed": "Не удалось изменить изображение",
+	"image.deleted":       "Изображение успешно удалено",
+	"image.delete_failed": "Не удалось удалить изображение",
+	"image.get_failed":    "Ошибка при получении изображения",
+	"image.not_found":     "изображение не найдено",
+
+	// Заказы
+	"order.checkout_failed":    "Не удалось оформить заказ",
+	"order.get_failed":         "Ошибка при получении заказа",
+	"order.list_failed":        "Ошибка при получении заказов",
+	"order.status_changed":     "Статус заказа изменён",
+	"order.status_failed":      "Не удалось изменить статус заказа",
+	"order.status_conflict":    "не удалось изменить статус заказа: статус уже изменён или неверный ID",
+	"order.unknown_status":     "неизвестный статус заказа: %s",
+	"order.invalid_transition": "недопустимый переход статуса заказа: %s -> %s",
+	"order.empty":              "заказ должен содержать хотя бы одну позицию",
+	"order.item_quantity":      "количество товара %s должно быть положительным числом",
+
+	// Резервы
+	"reservation.create_failed":   "Не удалось зарезервировать товар",
+	"reservation.get_failed":      "Ошибка при получении резерва",
+	"reservation.confirmed":       "Резерв подтверждён",
+	"reservation.confirm_failed":  "Не удалось подтвердить резерв",
+	"reservation.released":        "Резерв снят",
+	"reservation.release_failed":  "Не удалось снять резерв",
+	"reservation.quantity":        "количество резерва должно быть положительным числом",
+	"reservation.ttl_exceeded":    "срок резерва не может превышать %s",
+	"reservation.expired":         "срок резерва истёк",
+	"reservation.not_active":      "резерв уже в статусе %s",
+	"reservation.status_conflict": "не удалось изменить статус резерва: статус уже изменён или неверный ID",
+
+	// Пробы готовности
+	"health.check_failed":    "не удалось проверить зависимость",
+	"health.schema_mismatch": "версия схемы %d не совпадает с ожидаемой %d",
+
+	// Ошибки предметной области и базы данных
+	"error.not_found":          "объект не найден",
+	"error.conflict":           "конфликт с текущим состоянием данных",
+	"error.validation":         "некорректные данные",
+	"error.insufficient_stock": "недостаточно товара на складе",
+	"error.duplicate_phone":    "поставщик с таким номером телефона уже существует",
+	"error.version_mismatch":   "объект изменён после того, как его прочитали; получите актуальную версию и повторите запрос",
+	"error.unauthorized":       "требуется аутентификация",
+	"error.forbidden":          "недостаточно прав",
+	"error.duplicate":          "запись с такими данными уже существует",
+	"error.referenced":         "объект используется другими записями",
+	"error.reference_missing":  "связанный объект не найден",
+	"error.constraint":         "данные не удовлетворяют ограничениям",
+	"error.out_of_range":       "значение выходит за допустимый диапазон",
+}
@@ -0,0 +1,22 @@
+package logger
+
+import "context"
+
+// RequestIDKey — имя атрибута записи и заголовок ответа с идентификатором запроса.
+const RequestIDKey = "request_id"
+
+type requestIDKey struct{}
+
+// WithRequestID сохраняет идентификатор запроса в ctx. Все записи журнала,
+// сделанные с этим контекстом в обработчиках, сервисах и репозиториях, получают атрибут request_id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys — атрибуты с персональными данными и секретами. Их значения не попадают в журнал,
+// в каком бы месте записи и под какой группой они ни встретились.
+var sensitiveKeys = map[string]struct{}{
+	"birthday":      {},
+	"password":      {},
+	"password_hash": {},
+	"email":         {},
+	"phone_number":  {},
+	"token":         {},
+	"access_token":  {},
+	"refresh_token": {},
+	"authorization": {},
+	"x-api-key":     {},
+	"api_key":       {},
+}
+
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(attr.Key)]; ok {
+		return slog.String(attr.Key, redacted)
+	}
+	return attr
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql   string
+	start time.Time
+}
+
+// QueryTracer пишет каждый SQL-запрос в журнал на уровне debug. Аргументы запроса
+// не записываются: в них бывают персональные данные.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !slog.Default().Enabled(ctx, slog.LevelDebug) {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: strings.Join(strings.Fields(data.SQL), " "), start: time.Now()})
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("sql", query.sql),
+		slog.Duration("duration", time.Since(query.start)),
+		slog.Int64("rows", data.CommandTag.RowsAffected()),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, Err(data.Err))
+	}
+	slog.LogAttrs(ctx, slog.LevelDebug, "query", attrs...)
+}
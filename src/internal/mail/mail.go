@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender доставляет письма клиентам. Реализация выбирается в настройках mail.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogSender не отправляет письма, а записывает их в w (файл или стандартный вывод).
+// Используется для локального запуска, когда почтового сервера нет.
+type LogSender struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+func NewLogSender(w io.Writer, from string) *LogSender {
+	return &LogSender{w: w, from: from}
+}
+
+func (s *LogSender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(s.w, "Date: %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC1123Z), s.from, msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("ошибка при записи письма: %w", err)
+	}
+
+	return nil
+}
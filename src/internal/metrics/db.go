@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"src/internal/repository/model"
+	"time"
+)
+
+var queryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Время выполнения SQL-запросов по методам репозитория.",
+	Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"method", "outcome"})
+
+type queryNameKey struct{}
+
+// WithQueryName помечает запросы, выполняемые с ctx, именем метода репозитория.
+func WithQueryName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, queryNameKey{}, name)
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name  string
+	start time.Time
+}
+
+// QueryTracer измеряет время запросов, помеченных WithQueryName. Остальные запросы
+// (миграции, служебные запросы пула и транзакций) не учитываются.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	name, ok := ctx.Value(queryNameKey{}).(string)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: name, start: time.Now()})
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	outcome := "ok"
+	if data.Err != nil {
+		outcome = "error"
+	}
+	queryDuration.WithLabelValues(query.name, outcome).Observe(time.Since(query.start).Seconds())
+}
+
+// poolCollector снимает показатели пула соединений в момент сбора метрик.
+type poolCollector struct {
+	stats func() model.PoolStats
+
+	maxConns      *prometheus.Desc
+	totalConns    *prometheus.Desc
+	acquiredConns *prometheus.Desc
+	idleConns     *prometheus.Desc
+	acquireCount  *prometheus.Desc
+	emptyAcquires *prometheus.Desc
+	acquireTime   *prometheus.Desc
+}
+
+// RegisterPool добавляет в реестр показатели пула соединений, получаемые из stats.
+func RegisterPool(stats func() model.PoolStats) {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	Registry.MustRegister(&poolCollector{
+		stats:         stats,
+		maxConns:      desc("max_conns", "Максимальный размер пула соединений."),
+		totalConns:    desc("total_conns", "Текущее число соединений в пуле."),
+		acquiredConns: desc("acquired_conns", "Число занятых соединений."),
+		idleConns:     desc("idle_conns", "Число свободных соединений."),
+		acquireCount:  desc("acquires_total", "Количество выдач соединений из пула."),
+		emptyAcquires: desc("empty_acquires_total", "Количество выдач, которым пришлось ждать свободное соединение."),
+		acquireTime:   desc("acquire_duration_seconds_total", "Суммарное время ожидания соединений."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.totalConns
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquires
+	ch <- c.acquireTime
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stats.MaxConns))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stats.AcquiredConns))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stats.AcquireCount))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stats.EmptyAcquireCount))
+	ch <- prometheus.MustNewConstMetric(c.acquireTime, prometheus.CounterValue, stats.AcquireDuration.Seconds())
+}
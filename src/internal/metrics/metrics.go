@@ -0,0 +1,95 @@
+// Package metrics содержит метрики Prometheus, которые отдаёт маршрут /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"strconv"
+	"time"
+)
+
+const namespace = "shop"
+
+// Registry — реестр метрик приложения. Кроме метрик ниже в нём есть стандартные метрики Go и процесса.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// HTTP
+var (
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Количество обработанных HTTP-запросов.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки HTTP-запросов.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// ObserveRequest учитывает обработанный запрос. route — шаблон маршрута (/product/:id),
+// а не фактический путь, чтобы число рядов не зависело от идентификаторов в URL.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// Бизнес-события
+var (
+	productsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "products_created_total",
+		Help:      "Количество созданных товаров.",
+	})
+
+	stockReductions = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_reductions_total",
+		Help:      "Количество успешных списаний остатка товара при продаже: прямых, по позициям заказов и по подтверждённым резервам.",
+	})
+
+	insufficientStock = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "insufficient_stock_rejections_total",
+		Help:      "Количество запросов, отклонённых из-за нехватки товара на складе.",
+	}, []string{"route"})
+
+	imageBytesServed = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "image_bytes_served_total",
+		Help:      "Объём отданных изображений товаров в байтах.",
+	})
+)
+
+func ProductCreated() {
+	productsCreated.Inc()
+}
+
+// StockReduced учитывает count списаний. Вызывается только после фиксации транзакции,
+// чтобы откаченные списания не попадали в метрику.
+func StockReduced(count int) {
+	stockReductions.Add(float64(count))
+}
+
+func InsufficientStockRejected(route string) {
+	insufficientStock.WithLabelValues(route).Inc()
+}
+
+func ImageServed(bytes int) {
+	imageBytesServed.Add(float64(bytes))
+}
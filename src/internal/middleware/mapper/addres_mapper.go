@@ -12,3 +12,13 @@ func ToAddressModel(dto response.CreateUpdateAddress) model.Address {
 		Street:  dto.Street,
 	}
 }
+
+func ToAddressResponse(address model.Address) response.AddressResponse {
+	return response.AddressResponse{
+		Id:      address.ID.String(),
+		Country: address.Country,
+		City:    address.City,
+		Street:  address.Street,
+		Version: address.Version,
+	}
+}
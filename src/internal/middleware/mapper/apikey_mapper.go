@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"src/internal/api/response"
+	"src/internal/repository/model"
+	"time"
+)
+
+func ToAPIKeyResponse(key model.APIKey) response.APIKeyResponse {
+	return response.APIKeyResponse{
+		ID:         key.ID.String(),
+		SupplierID: key.SupplierID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  formatOptionalTime(key.ExpiresAt),
+		RevokedAt:  formatOptionalTime(key.RevokedAt),
+		LastUsedAt: formatOptionalTime(key.LastUsedAt),
+		CreatedAt:  key.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func ToIssuedAPIKeyResponse(key model.APIKey, plainText string) response.IssuedAPIKeyResponse {
+	return response.IssuedAPIKeyResponse{
+		Key:    plainText,
+		APIKey: ToAPIKeyResponse(key),
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,16 @@
+package mapper
+
+import (
+	"src/internal/api/response"
+	"src/internal/repository/model"
+	"time"
+)
+
+func ToTokenResponse(tokens model.TokenPair) response.TokenResponse {
+	return response.TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(tokens.ExpiresAt).Seconds()),
+	}
+}
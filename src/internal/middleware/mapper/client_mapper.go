@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"src/internal/api/response"
+	"src/internal/domain"
+	"src/internal/repository/model"
+	"time"
+)
+
+func ToProfileModel(req response.UpdateProfile) (model.User, error) {
+	birthday, err := time.Parse("2006-01-02", req.Birthday)
+	if err != nil {
+		return model.User{}, domain.Invalid("birthday", "validation.iso_date")
+	}
+
+	return model.User{
+		ClientName:    req.ClientName,
+		ClientSurname: req.ClientSurname,
+		Birthday:      birthday,
+		Gender:        req.Gender,
+	}, nil
+}
+
+func ToUpdateProfile(user model.User) response.UpdateProfile {
+	return response.UpdateProfile{
+		ClientName:    user.ClientName,
+		ClientSurname: user.ClientSurname,
+		Birthday:      user.Birthday.Format("2006-01-02"),
+		Gender:        user.Gender,
+	}
+}
+
+func ToProfileResponse(user model.User, address model.Address) response.ProfileResponse {
+	return response.ProfileResponse{
+		ID:            user.ID.String(),
+		ClientName:    user.ClientName,
+		ClientSurname: user.ClientSurname,
+		Birthday:      user.Birthday.Format("2006-01-02"),
+		Gender:        user.Gender,
+		Registration:  user.RegistrationDate.Format("2006-01-02T15:04:05Z"),
+		Version:       user.Version,
+		Address:       ToAddressResponse(address),
+	}
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"src/internal/api/response"
+	"src/internal/domain"
+	"src/internal/repository/model"
+)
+
+func ToOrderItemModels(req response.Checkout) ([]model.OrderItem, error) {
+	items := make([]model.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		productID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			return nil, domain.Invalid(fmt.Sprintf("items[%d].product_id", i), "validation.uuid")
+		}
+		items[i] = model.OrderItem{
+			ProductID: productID,
+			Quantity:  item.Quantity,
+		}
+	}
+	return items, nil
+}
+
+func ToOrderResponse(order model.Order) response.OrderResponse {
+	items := make([]response.OrderItemResponse, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = response.OrderItemResponse{
+			ID:        item.ID.String(),
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		}
+	}
+
+	return response.OrderResponse{
+		ID:        order.ID.String(),
+		ClientID:  order.ClientID.String(),
+		Status:    order.Status,
+		Total:     order.Total,
+		CreatedAt: order.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		Items:     items,
+	}
+}
@@ -1,16 +1,16 @@
 package mapper
 
 import (
-	"fmt"
 	"github.com/google/uuid"
 	"src/internal/api/response"
+	"src/internal/domain"
 	"src/internal/repository/model"
 )
 
-func ToProductModel(req response.CreateProduct) model.Product {
+func ToProductModel(req response.CreateProduct) (model.Product, error) {
 	supplierId, err := uuid.Parse(req.SupplierID)
 	if err != nil {
-		fmt.Printf("Ошибка парсинга supplierID: %s\n", err)
+		return model.Product{}, domain.Invalid("supplierID", "validation.uuid")
 	}
 	return model.Product{
 		Name:           req.Name,
@@ -18,7 +18,7 @@ func ToProductModel(req response.CreateProduct) model.Product {
 		Price:          req.Price,
 		AvailableStock: req.AvailableStock,
 		SupplierID:     supplierId,
-	}
+	}, nil
 }
 
 func ToProductResponse(product model.Product) response.ProductResponse {
@@ -34,8 +34,44 @@ func ToProductResponse(product model.Product) response.ProductResponse {
 		Category:       product.Category,
 		Price:          product.Price,
 		AvailableStock: product.AvailableStock,
+		ReservedStock:  product.ReservedStock,
 		LastUpdateDate: product.LastUpdateDate.String(),
 		SupplierID:     product.SupplierID.String(),
 		ImageID:        imageId,
+		Version:        product.Version,
+		DeletedAt:      formatOptionalTime(product.DeletedAt),
+	}
+}
+
+func ToProductMatchResponse(match model.ProductMatch) response.ProductMatchResponse {
+	return response.ProductMatchResponse{
+		Product: ToProductResponse(match.Product),
+		Rank:    match.Rank,
+		Highlight: response.ProductHighlight{
+			Name:     match.NameHighlight,
+			Category: match.CategoryHighlight,
+		},
+	}
+}
+
+func ToUpdateProduct(product model.Product) response.UpdateProduct {
+	return response.UpdateProduct{
+		Name:       product.Name,
+		Category:   product.Category,
+		Price:      &product.Price,
+		SupplierID: product.SupplierID.String(),
 	}
 }
+
+func ToUpdatedProductModel(req response.UpdateProduct) (model.Product, error) {
+	supplierId, err := uuid.Parse(req.SupplierID)
+	if err != nil {
+		return model.Product{}, domain.Invalid("supplierID", "validation.uuid")
+	}
+	return model.Product{
+		Name:       req.Name,
+		Category:   req.Category,
+		Price:      *req.Price,
+		SupplierID: supplierId,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package mapper
+
+import (
+	"src/internal/api/response"
+	"src/internal/repository/model"
+)
+
+func ToReservationResponse(reservation model.Reservation) response.ReservationResponse {
+	return response.ReservationResponse{
+		ID:        reservation.ID.String(),
+		ProductID: reservation.ProductID.String(),
+		Quantity:  reservation.Quantity,
+		Status:    reservation.Status,
+		ExpiresAt: reservation.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		CreatedAt: reservation.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"src/internal/api/response"
+	"src/internal/repository/model"
+)
+
+func ToStockMovementResponse(movement model.StockMovement) response.StockMovementResponse {
+	return response.StockMovementResponse{
+		ID:          movement.ID.String(),
+		ProductID:   movement.ProductID.String(),
+		ProductName: movement.ProductName,
+		Delta:       movement.Delta,
+		Reason:      movement.Reason,
+		Actor:       movement.Actor,
+		CreatedAt:   movement.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -14,9 +14,25 @@ func ToSupplierModel(req response.CreateSupplier) model.Supplier {
 
 func ToSupplierResponse(supplier model.Supplier) response.SupplierResponse {
 	return response.SupplierResponse{
-		ID:      supplier.ID.String(),
-		Name:    supplier.Name,
-		Address: supplier.AddressID.String(),
-		Phone:   supplier.PhoneNumber,
+		ID:        supplier.ID.String(),
+		Name:      supplier.Name,
+		Address:   supplier.AddressID.String(),
+		Phone:     supplier.PhoneNumber,
+		Version:   supplier.Version,
+		DeletedAt: formatOptionalTime(supplier.DeletedAt),
+	}
+}
+
+func ToUpdateSupplier(supplier model.Supplier) response.UpdateSupplier {
+	return response.UpdateSupplier{
+		Name:  supplier.Name,
+		Phone: supplier.PhoneNumber,
+	}
+}
+
+func ToUpdatedSupplierModel(req response.UpdateSupplier) model.Supplier {
+	return model.Supplier{
+		Name:        req.Name,
+		PhoneNumber: req.Phone,
 	}
 }
@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"src/internal/api/response"
+	"src/internal/repository/model"
+)
+
+func ToPoolStatsResponse(stats model.PoolStats) response.PoolStatsResponse {
+	return response.PoolStatsResponse{
+		MaxConns:                stats.MaxConns,
+		TotalConns:              stats.TotalConns,
+		AcquiredConns:           stats.AcquiredConns,
+		IdleConns:               stats.IdleConns,
+		ConstructingConns:       stats.ConstructingConns,
+		AcquireCount:            stats.AcquireCount,
+		AcquireDuration:         stats.AcquireDuration.String(),
+		EmptyAcquireCount:       stats.EmptyAcquireCount,
+		CanceledAcquireCount:    stats.CanceledAcquireCount,
+		NewConnsCount:           stats.NewConnsCount,
+		MaxLifetimeDestroyCount: stats.MaxLifetimeDestroyCount,
+		MaxIdleDestroyCount:     stats.MaxIdleDestroyCount,
+	}
+}
+
+// ToDependencyResponse не заполняет Error: текст ошибки переводится на язык запроса в обработчике.
+func ToDependencyResponse(check model.DependencyCheck) response.DependencyResponse {
+	dependency := response.DependencyResponse{
+		Status:    response.StatusOK,
+		LatencyMs: float64(check.Duration.Microseconds()) / 1000,
+	}
+	if !check.Healthy {
+		dependency.Status = response.StatusUnavailable
+	}
+	if check.Name == model.DependencyMigrations {
+		dependency.Version = &check.Version
+		dependency.ExpectedVersion = &check.ExpectedVersion
+	}
+
+	return dependency
+}
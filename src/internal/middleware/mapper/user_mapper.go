@@ -2,19 +2,23 @@ package mapper
 
 import (
 	"src/internal/api/response"
+	"src/internal/domain"
 	"src/internal/repository/model"
 	"time"
 )
 
-func ToUserModel(req response.CreateUser) model.User {
-	birthday, _ := time.Parse("2006-01-02", req.Birthday)
+func ToUserModel(req response.CreateUser) (model.User, error) {
+	birthday, err := time.Parse("2006-01-02", req.Birthday)
+	if err != nil {
+		return model.User{}, domain.Invalid("birthday", "validation.iso_date")
+	}
 
 	return model.User{
 		ClientName:    req.ClientName,
 		ClientSurname: req.ClientSurname,
 		Birthday:      birthday,
 		Gender:        req.Gender,
-	}
+	}, nil
 }
 
 func ToUserResponse(user model.User) response.UserResponse {
@@ -26,5 +30,7 @@ func ToUserResponse(user model.User) response.UserResponse {
 		Gender:        user.Gender,
 		Registration:  user.RegistrationDate.Format("2006-01-02T15:04:05Z"), // ISO 8601
 		Address:       user.AddressID.String(),
+		Version:       user.Version,
+		DeletedAt:     formatOptionalTime(user.DeletedAt),
 	}
 }
@@ -0,0 +1,338 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockID — ключ advisory-блокировки, общий для всех экземпляров приложения.
+const lockID int64 = 7_240_115_001
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []Migration
+}
+
+func New(db *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при чтении каталога миграций: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		matches := fileNameRe.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("некорректная версия миграции %s: %w", entry.Name(), err)
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при чтении миграции %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+		if matches[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("для миграции %d отсутствует up-скрипт", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Latest возвращает версию последней встроенной миграции.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version возвращает версию последней применённой миграции.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var exists bool
+	err := m.db.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL;`).Scan(&exists)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при проверке таблицы миграций: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int64
+	err = m.db.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при получении версии схемы: %w", err)
+	}
+
+	return version, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.Goto(ctx, m.Latest())
+}
+
+// Down откатывает одну последнюю применённую миграцию.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				return revert(ctx, conn, m.migrations[i])
+			}
+		}
+
+		slog.InfoContext(ctx, "no migrations to revert")
+		return nil
+	})
+}
+
+// Goto применяет или откатывает миграции так, чтобы схема соответствовала версии target.
+func (m *Migrator) Goto(ctx context.Context, target int64) error {
+	if target != 0 && !m.has(target) {
+		return fmt.Errorf("миграция версии %d не найдена", target)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; ok && migration.Version > target {
+				if err := revert(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; !ok && migration.Version <= target {
+				if err := apply(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Baseline отмечает миграции до версии target включительно применёнными, не выполняя их.
+// Нужна для баз, схема которых уже создана вручную: иначе up падает на первой же
+// миграции с ошибкой «relation already exists». Уже применённые миграции не меняются.
+func (m *Migrator) Baseline(ctx context.Context, target int64) error {
+	if !m.has(target) {
+		return fmt.Errorf("миграция версии %d не найдена", target)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > target {
+				continue
+			}
+
+			_, err := conn.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`,
+				migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("ошибка при отметке миграции %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			slog.InfoContext(ctx, "baselined migration", "version", migration.Version, "name", migration.Name)
+		}
+
+		return nil
+	})
+}
+
+func (m *Migrator) has(version int64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// withLock выполняет fn на выделенном соединении под advisory-блокировкой,
+// чтобы одновременно запущенные экземпляры не применяли миграции параллельно.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) (err error) {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении соединения: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1);`, lockID); err != nil {
+		return fmt.Errorf("ошибка при захвате блокировки миграций: %w", err)
+	}
+	defer func() {
+		if _, unlockErr := conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1);`, lockID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("ошибка при снятии блокировки миграций: %w", unlockErr))
+		}
+	}()
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("ошибка при создании таблицы миграций: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении применённых миграций: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", err)
+	}
+
+	return applied, nil
+}
+
+func apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`,
+			migration.Version, migration.Name)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка при применении миграции %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	slog.InfoContext(ctx, "applied migration", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+func revert(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("для миграции %d отсутствует down-скрипт", migration.Version)
+	}
+
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1;`, migration.Version)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка при откате миграции %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	slog.InfoContext(ctx, "reverted migration", "version", migration.Version, "name", migration.Name)
+	return nil
+}
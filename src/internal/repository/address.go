@@ -2,17 +2,19 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"src/internal/repository/model"
 )
 
 type AddressPostgres struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
 }
 
-func NewAddressPostgres(db *pgx.Conn) *AddressPostgres {
+func NewAddressPostgres(db *pgxpool.Pool) *AddressPostgres {
 	return &AddressPostgres{db: db}
 }
 
@@ -24,33 +26,86 @@ func (r *AddressPostgres) CreateAddress(ctx context.Context, address model.Addre
 	`
 
 	var addressID uuid.UUID
-	err := r.db.QueryRow(ctx, query, address.Country, address.City, address.Street).Scan(&addressID)
+	err := querier(ctx, r.db).QueryRow(ctx, query, address.Country, address.City, address.Street).Scan(&addressID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении адреса: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении адреса: %w", translateError(err))
 	}
 
 	return addressID, nil
 }
 
-func (r *AddressPostgres) DeleteAddress(ctx context.Context, addressID uuid.UUID) error {
-	query := `DELETE FROM address WHERE id = $1;`
-	_, err := r.db.Exec(ctx, query, addressID)
+func (r *AddressPostgres) GetAddressByID(ctx context.Context, addressID uuid.UUID) (model.Address, error) {
+	query := `SELECT id, country, city, street, version FROM address WHERE id = $1;`
+
+	var address model.Address
+	err := querier(ctx, r.db).QueryRow(ctx, query, addressID).Scan(&address.ID, &address.Country, &address.City, &address.Street,
+		&address.Version)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении адреса: %w", err)
+		return model.Address{}, fmt.Errorf("ошибка при получении адреса: %w", translateError(err))
 	}
-	return nil
+
+	return address, nil
 }
 
-func (r *AddressPostgres) UpdateAddress(ctx context.Context, address model.Address) error {
+// UpdateAddress изменяет адрес, если его версия совпадает с address.Version,
+// и возвращает новое состояние.
+func (r *AddressPostgres) UpdateAddress(ctx context.Context, address model.Address) (model.Address, error) {
 	query := `
 	UPDATE address
-	SET country = $1, city = $2, street = $3
-	WHERE id = $4;
+	SET country = $1, city = $2, street = $3, version = version + 1
+	WHERE id = $4 AND version = $5
+	RETURNING id, country, city, street, version;
 	`
-	_, err := r.db.Exec(ctx, query, address.Country, address.City, address.Street, address.ID)
+
+	var updated model.Address
+	err := querier(ctx, r.db).QueryRow(ctx, query, address.Country, address.City, address.Street, address.ID, address.Version).Scan(
+		&updated.ID, &updated.Country, &updated.City, &updated.Street, &updated.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Address{}, versionError(ctx, r.db, "address", address.ID, "address.not_found")
+	}
 	if err != nil {
-		return fmt.Errorf("ошибка при обновлении адреса: %w", err)
+		return model.Address{}, fmt.Errorf("ошибка при обновлении адреса: %w", translateError(err))
+	}
+
+	return updated, nil
+}
+
+// DeleteAddresses удаляет адреса с указанными id. Отсутствующие адреса пропускаются.
+func (r *AddressPostgres) DeleteAddresses(ctx context.Context, addressIDs []uuid.UUID) error {
+	if len(addressIDs) == 0 {
+		return nil
 	}
 
+	query := `DELETE FROM address WHERE id = ANY($1);`
+	if _, err := querier(ctx, r.db).Exec(ctx, query, addressIDs); err != nil {
+		return fmt.Errorf("ошибка при удалении адресов: %w", translateError(err))
+	}
 	return nil
 }
+
+// scanAddressIDs читает столбец address_id из строк, возвращённых при очистке владельцев адресов,
+// и возвращает число строк и непустые id адресов.
+func scanAddressIDs(rows pgx.Rows) (int, []uuid.UUID, error) {
+	defer rows.Close()
+
+	var (
+		count      int
+		addressIDs []uuid.UUID
+	)
+	for rows.Next() {
+		var addressID *uuid.UUID
+		if err := rows.Scan(&addressID); err != nil {
+			return 0, nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		count++
+		if addressID != nil {
+			addressIDs = append(addressIDs, *addressID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return count, addressIDs, nil
+}
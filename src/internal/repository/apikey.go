@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
+	"src/internal/repository/model"
+)
+
+type APIKeyPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKeyPostgres(db *pgxpool.Pool) *APIKeyPostgres {
+	return &APIKeyPostgres{db: db}
+}
+
+const apiKeyColumns = `id, supplier_id, name, prefix, key_hash, scopes, expires_at, revoked_at, last_used_at, created_at`
+
+func scanAPIKey(row pgx.Row) (model.APIKey, error) {
+	var key model.APIKey
+	err := row.Scan(&key.ID, &key.SupplierID, &key.Name, &key.Prefix, &key.KeyHash, &key.Scopes,
+		&key.ExpiresAt, &key.RevokedAt, &key.LastUsedAt, &key.CreatedAt)
+	return key, err
+}
+
+func (r *APIKeyPostgres) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	query := `
+		INSERT INTO supplier_api_keys (supplier_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+		RETURNING id, created_at;
+	`
+
+	err := querier(ctx, r.db).QueryRow(ctx, query, key.SupplierID, key.Name, key.Prefix, key.KeyHash, key.Scopes,
+		key.ExpiresAt).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return model.APIKey{}, fmt.Errorf("ошибка при создании API-ключа: %w", translateError(err))
+	}
+
+	return key, nil
+}
+
+// apiKeySortKey задаёт порядок ключей поставщика: от новых к старым, затем по id.
+var apiKeySortKey = keyset{column: "created_at", cast: "timestamp"}
+
+// GetSupplierAPIKeys возвращает страницу ключей поставщика, начиная с последних выпущенных.
+// Страница содержит на одну строку больше лимита, если за ней есть ещё ключи.
+func (r *APIKeyPostgres) GetSupplierAPIKeys(ctx context.Context, supplierID uuid.UUID, page model.Page) ([]model.APIKey, error) {
+	clause, args := apiKeySortKey.paginate(page, true, []string{"supplier_id = $1"}, []any{supplierID})
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM supplier_api_keys` + clause + `;`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении API-ключей: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var keys []model.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return keys, nil
+}
+
+// GetAPIKeyByHash возвращает ключ по хэшу. Ключи удалённого поставщика не находятся.
+func (r *APIKeyPostgres) GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM supplier_api_keys k
+		WHERE key_hash = $1
+		  AND EXISTS (SELECT 1 FROM supplier s WHERE s.id = k.supplier_id AND s.deleted_at IS NULL);
+	`
+
+	key, err := scanAPIKey(querier(ctx, r.db).QueryRow(ctx, query, keyHash))
+	if err != nil {
+		return model.APIKey{}, fmt.Errorf("ошибка при получении API-ключа: %w", translateError(err))
+	}
+
+	return key, nil
+}
+
+// GetSupplierAPIKeyForUpdate возвращает ключ поставщика и блокирует его строку до конца транзакции.
+// Ключ другого поставщика считается не найденным.
+func (r *APIKeyPostgres) GetSupplierAPIKeyForUpdate(ctx context.Context, supplierID, keyID uuid.UUID) (model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM supplier_api_keys WHERE id = $1 AND supplier_id = $2 FOR UPDATE;`
+
+	key, err := scanAPIKey(querier(ctx, r.db).QueryRow(ctx, query, keyID, supplierID))
+	if err != nil {
+		return model.APIKey{}, fmt.Errorf("ошибка при получении API-ключа: %w", translateError(err))
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyPostgres) RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	query := `
+		UPDATE supplier_api_keys
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND revoked_at IS NULL;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, keyID)
+	if err != nil {
+		return fmt.Errorf("ошибка при отзыве API-ключа: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrConflict, "api_key.revoked")
+	}
+	return nil
+}
+
+func (r *APIKeyPostgres) TouchAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	query := `UPDATE supplier_api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1;`
+
+	_, err := querier(ctx, r.db).Exec(ctx, query, keyID)
+	if err != nil {
+		return fmt.Errorf("ошибка при обновлении времени использования API-ключа: %w", translateError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
+	"src/internal/repository/model"
+)
+
+type ClientAccountPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewClientAccountPostgres(db *pgxpool.Pool) *ClientAccountPostgres {
+	return &ClientAccountPostgres{db: db}
+}
+
+func (r *ClientAccountPostgres) CreateClientCredentials(ctx context.Context, credentials model.ClientCredentials) error {
+	query := `
+		INSERT INTO client_credentials (client_id, email, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+	`
+
+	_, err := querier(ctx, r.db).Exec(ctx, query, credentials.ClientID, credentials.Email, credentials.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении учётных данных клиента: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// GetClientCredentialsByEmail возвращает учётные данные клиента по адресу почты.
+// Учётные данные удалённого клиента не находятся, поэтому войти он не может.
+func (r *ClientAccountPostgres) GetClientCredentialsByEmail(ctx context.Context, email string) (model.ClientCredentials, error) {
+	query := `
+		SELECT client_id, email, password_hash, email_verified_at, created_at, updated_at
+		FROM client_credentials cc
+		WHERE email = $1
+		  AND EXISTS (SELECT 1 FROM client c WHERE c.id = cc.client_id AND c.deleted_at IS NULL);
+	`
+
+	var credentials model.ClientCredentials
+	err := querier(ctx, r.db).QueryRow(ctx, query, email).Scan(&credentials.ClientID, &credentials.Email,
+		&credentials.PasswordHash, &credentials.EmailVerifiedAt, &credentials.CreatedAt, &credentials.UpdatedAt)
+	if err != nil {
+		return model.ClientCredentials{}, fmt.Errorf("ошибка при получении учётных данных клиента: %w", translateError(err))
+	}
+
+	return credentials, nil
+}
+
+func (r *ClientAccountPostgres) SetClientPassword(ctx context.Context, clientID uuid.UUID, passwordHash string) error {
+	query := `
+		UPDATE client_credentials
+		SET password_hash = $1,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE client_id = $2;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, passwordHash, clientID)
+	if err != nil {
+		return fmt.Errorf("ошибка при изменении пароля клиента: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrNotFound, "user.not_found")
+	}
+	return nil
+}
+
+func (r *ClientAccountPostgres) MarkClientEmailVerified(ctx context.Context, clientID uuid.UUID) error {
+	query := `
+		UPDATE client_credentials
+		SET email_verified_at = COALESCE(email_verified_at, CURRENT_TIMESTAMP),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE client_id = $1;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, clientID)
+	if err != nil {
+		return fmt.Errorf("ошибка при подтверждении адреса почты: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrNotFound, "user.not_found")
+	}
+	return nil
+}
+
+func (r *ClientAccountPostgres) CreateClientToken(ctx context.Context, token model.ClientToken) error {
+	query := `
+		INSERT INTO client_tokens (client_id, purpose, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP);
+	`
+
+	_, err := querier(ctx, r.db).Exec(ctx, query, token.ClientID, token.Purpose, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении токена клиента: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// GetClientTokenForUpdate блокирует строку токена до конца текущей транзакции,
+// чтобы один токен нельзя было использовать дважды.
+func (r *ClientAccountPostgres) GetClientTokenForUpdate(ctx context.Context, purpose, tokenHash string) (model.ClientToken, error) {
+	query := `
+		SELECT id, client_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM client_tokens
+		WHERE purpose = $1 AND token_hash = $2
+		FOR UPDATE;
+	`
+
+	var token model.ClientToken
+	err := querier(ctx, r.db).QueryRow(ctx, query, purpose, tokenHash).Scan(&token.ID, &token.ClientID, &token.Purpose,
+		&token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt)
+	if err != nil {
+		return model.ClientToken{}, fmt.Errorf("ошибка при получении токена клиента: %w", translateError(err))
+	}
+
+	return token, nil
+}
+
+func (r *ClientAccountPostgres) MarkClientTokenUsed(ctx context.Context, tokenID uuid.UUID) error {
+	query := `
+		UPDATE client_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, tokenID)
+	if err != nil {
+		return fmt.Errorf("ошибка при использовании токена клиента: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrValidation, "client.invalid_token")
+	}
+	return nil
+}
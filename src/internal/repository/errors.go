@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
+	"strings"
+)
+
+// Коды ошибок PostgreSQL, которые переводятся в ошибки предметной области.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgNotNullViolation    = "23502"
+	pgStringTooLong       = "22001"
+	pgInvalidText         = "22P02"
+	pgOutOfRange          = "22003"
+)
+
+// constraintErrors сопоставляет ограничения схемы с конкретными ошибками предметной области.
+var constraintErrors = map[string]struct {
+	kind error
+	key  string
+}{
+	"supplier_phone_number_key":         {domain.ErrDuplicatePhone, "error.duplicate_phone"},
+	"product_available_stock_check":     {domain.ErrInsufficientStock, "error.insufficient_stock"},
+	"product_reserved_within_available": {domain.ErrInsufficientStock, "error.insufficient_stock"},
+	"staff_users_login_key":             {domain.ErrConflict, "staff.duplicate_login"},
+	"client_credentials_email_key":      {domain.ErrConflict, "client.duplicate_email"},
+}
+
+// translateError переводит pgx.ErrNoRows и ошибки PostgreSQL в ошибки пакета domain.
+// Исходная ошибка сохраняется в цепочке, но не попадает в сообщение для клиента.
+func translateError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if constraint, ok := constraintErrors[pgErr.ConstraintName]; ok {
+		return domain.Wrap(constraint.kind, err, constraint.key)
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return domain.Wrap(domain.ErrConflict, err, "error.duplicate")
+	case pgForeignKeyViolation:
+		if strings.HasPrefix(pgErr.Message, "update or delete") {
+			return domain.Wrap(domain.ErrConflict, err, "error.referenced")
+		}
+		return domain.Wrap(domain.ErrValidation, err, "error.reference_missing")
+	case pgCheckViolation, pgNotNullViolation, pgStringTooLong, pgInvalidText:
+		return domain.Wrap(domain.ErrValidation, err, "error.constraint")
+	case pgOutOfRange:
+		return domain.Wrap(domain.ErrValidation, err, "error.out_of_range")
+	}
+
+	return err
+}
+
+// softDeleteTables перечисляет таблицы с мягким удалением: строки с заполненным deleted_at
+// считаются отсутствующими для всех операций, кроме восстановления.
+var softDeleteTables = map[string]bool{"product": true, "client": true, "supplier": true}
+
+// rowExists проверяет, есть ли в таблице строка с id. Если live, удалённые строки не учитываются.
+func rowExists(ctx context.Context, db *pgxpool.Pool, table string, id uuid.UUID, live bool) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM ` + table + ` WHERE id = $1`
+	if live && softDeleteTables[table] {
+		query += ` AND deleted_at IS NULL`
+	}
+	query += `);`
+
+	var exists bool
+	if err := querier(ctx, db).QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("ошибка при проверке строки: %w", translateError(err))
+	}
+	return exists, nil
+}
+
+// versionError объясняет, почему изменение строки с проверкой версии не затронуло ни одной
+// строки: строки нет вовсе (или она удалена) или её уже изменил другой запрос.
+func versionError(ctx context.Context, db *pgxpool.Pool, table string, id uuid.UUID, notFoundKey string) error {
+	exists, err := rowExists(ctx, db, table, id, true)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return domain.New(domain.ErrNotFound, notFoundKey)
+	}
+
+	return domain.New(domain.ErrVersionMismatch, "error.version_mismatch")
+}
+
+// restoreError объясняет, почему восстановление не затронуло ни одной строки:
+// строки нет вовсе или она не удалена.
+func restoreError(ctx context.Context, db *pgxpool.Pool, table string, id uuid.UUID, notFoundKey, notDeletedKey string) error {
+	exists, err := rowExists(ctx, db, table, id, false)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return domain.New(domain.ErrNotFound, notFoundKey)
+	}
+
+	return domain.New(domain.ErrConflict, notDeletedKey)
+}
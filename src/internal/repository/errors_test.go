@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"src/internal/domain"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection reset")
+
+	tests := []struct {
+		name     string
+		err      error
+		wantKind error
+		wantKey  string
+	}{
+		{name: "no rows", err: pgx.ErrNoRows, wantKind: domain.ErrNotFound},
+		{
+			name:     "known constraint",
+			err:      &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "supplier_phone_number_key"},
+			wantKind: domain.ErrDuplicatePhone,
+			wantKey:  "error.duplicate_phone",
+		},
+		{name: "unique violation", err: &pgconn.PgError{Code: pgUniqueViolation}, wantKind: domain.ErrConflict, wantKey: "error.duplicate"},
+		{
+			name:     "referenced row",
+			err:      &pgconn.PgError{Code: pgForeignKeyViolation, Message: "update or delete on table \"product\" violates foreign key constraint"},
+			wantKind: domain.ErrConflict,
+			wantKey:  "error.referenced",
+		},
+		{
+			name:     "missing reference",
+			err:      &pgconn.PgError{Code: pgForeignKeyViolation, Message: "insert or update on table \"product\" violates foreign key constraint"},
+			wantKind: domain.ErrValidation,
+			wantKey:  "error.reference_missing",
+		},
+		{name: "check violation", err: &pgconn.PgError{Code: pgCheckViolation}, wantKind: domain.ErrValidation, wantKey: "error.constraint"},
+		{name: "integer out of range", err: &pgconn.PgError{Code: pgOutOfRange}, wantKind: domain.ErrValidation, wantKey: "error.out_of_range"},
+		{name: "other error", err: other, wantKind: other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.err)
+
+			if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("err = %v, want %v", err, tt.wantKind)
+			}
+			if tt.wantKey == "" {
+				return
+			}
+
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) || domainErr.Key != tt.wantKey {
+				t.Fatalf("err = %v, want key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
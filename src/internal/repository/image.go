@@ -4,15 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
 	"src/internal/repository/model"
 )
 
 type ImagePostgres struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
 }
 
-func NewImagePostgres(db *pgx.Conn) *ImagePostgres {
+func NewImagePostgres(db *pgxpool.Pool) *ImagePostgres {
 	return &ImagePostgres{db: db}
 }
 
@@ -24,9 +25,9 @@ func (r *ImagePostgres) AddImage(ctx context.Context, image model.Image) (uuid.U
 	`
 
 	var imageID uuid.UUID
-	err := r.db.QueryRow(ctx, query, image.Image).Scan(&imageID)
+	err := querier(ctx, r.db).QueryRow(ctx, query, image.Image).Scan(&imageID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении изображения: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении изображения: %w", translateError(err))
 	}
 
 	return imageID, nil
@@ -35,13 +36,18 @@ func (r *ImagePostgres) AddImage(ctx context.Context, image model.Image) (uuid.U
 func (r *ImagePostgres) AddImageToProduct(ctx context.Context, productID uuid.UUID, imageID uuid.UUID) error {
 	query := `
 		UPDATE product 
-		SET image_id = $1 
-		WHERE id = $2;
+		SET image_id = $1,
+		    version = version + 1
+		WHERE id = $2 AND deleted_at IS NULL;
 	`
 
-	_, err := r.db.Exec(ctx, query, imageID, productID)
+	result, err := querier(ctx, r.db).Exec(ctx, query, imageID, productID)
 	if err != nil {
-		return fmt.Errorf("ошибка при добавлении изображения к продукту: %w", err)
+		return fmt.Errorf("ошибка при добавлении изображения к продукту: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrNotFound, "product.not_found")
 	}
 
 	return nil
@@ -54,9 +60,13 @@ func (r *ImagePostgres) UploadImage(ctx context.Context, image model.Image, imag
 		WHERE id = $2;
 	`
 
-	_, err := r.db.Exec(ctx, query, image.Image, imageID)
+	result, err := querier(ctx, r.db).Exec(ctx, query, image.Image, imageID)
 	if err != nil {
-		return fmt.Errorf("ошибка при изменении изображения: %w", err)
+		return fmt.Errorf("ошибка при изменении изображения: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrNotFound, "image.not_found")
 	}
 
 	return nil
@@ -67,9 +77,13 @@ func (r *ImagePostgres) DeleteImage(ctx context.Context, imageID uuid.UUID) erro
 		DELETE FROM images 
 		WHERE id = $1;
 	`
-	_, err := r.db.Exec(ctx, query, imageID)
+	result, err := querier(ctx, r.db).Exec(ctx, query, imageID)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении изображения: %w", err)
+		return fmt.Errorf("ошибка при удалении изображения: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrNotFound, "image.not_found")
 	}
 
 	return nil
@@ -78,12 +92,13 @@ func (r *ImagePostgres) DeleteImage(ctx context.Context, imageID uuid.UUID) erro
 func (r *ImagePostgres) DeleteImageIdFromProduct(ctx context.Context, imageID uuid.UUID) error {
 	query := `
 		UPDATE product 
-		SET image_id = NULL
+		SET image_id = NULL,
+		    version = version + 1
 		WHERE image_id = $1;
 	`
-	_, err := r.db.Exec(ctx, query, imageID)
+	_, err := querier(ctx, r.db).Exec(ctx, query, imageID)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении image_id из product: %w", err)
+		return fmt.Errorf("ошибка при удалении image_id из product: %w", translateError(err))
 	}
 
 	return nil
@@ -92,13 +107,13 @@ func (r *ImagePostgres) DeleteImageIdFromProduct(ctx context.Context, imageID uu
 func (r *ImagePostgres) GetImageIdByProductId(ctx context.Context, productId uuid.UUID) (uuid.UUID, error) {
 	query := `
 		SELECT image_id FROM product 
-		WHERE id = $1;
+		WHERE id = $1 AND deleted_at IS NULL;
 	`
 	var imageID uuid.UUID
 
-	err := r.db.QueryRow(ctx, query, productId).Scan(&imageID)
+	err := querier(ctx, r.db).QueryRow(ctx, query, productId).Scan(&imageID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при получении image_id: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при получении image_id: %w", translateError(err))
 	}
 
 	return imageID, nil
@@ -111,9 +126,9 @@ func (r *ImagePostgres) GetImageByProductId(ctx context.Context, productID uuid.
 	`
 
 	var image model.Image
-	err := r.db.QueryRow(ctx, query, productID).Scan(&image.ID, &image.Image)
+	err := querier(ctx, r.db).QueryRow(ctx, query, productID).Scan(&image.ID, &image.Image)
 	if err != nil {
-		return model.Image{}, fmt.Errorf("ошибка при получении изображения: %w", err)
+		return model.Image{}, fmt.Errorf("ошибка при получении изображения: %w", translateError(err))
 	}
 
 	return image, nil
@@ -126,9 +141,9 @@ func (r *ImagePostgres) GetImageById(ctx context.Context, imageID uuid.UUID) (mo
 	`
 
 	var image model.Image
-	err := r.db.QueryRow(ctx, query, imageID).Scan(&image.ID, &image.Image)
+	err := querier(ctx, r.db).QueryRow(ctx, query, imageID).Scan(&image.ID, &image.Image)
 	if err != nil {
-		return model.Image{}, fmt.Errorf("ошибка при получении изображения: %w", err)
+		return model.Image{}, fmt.Errorf("ошибка при получении изображения: %w", translateError(err))
 	}
 
 	return image, nil
@@ -9,4 +9,6 @@ type Address struct {
 	Country string
 	City    string
 	Street  string
+	// Version растёт при каждом изменении адреса; по ней выявляются конкурентные изменения.
+	Version int
 }
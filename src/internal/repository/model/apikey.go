@@ -0,0 +1,31 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Права, которые можно выдать API-ключу поставщика.
+const (
+	ScopeProductsRead = "products:read"
+	ScopeStockWrite   = "stock:write"
+	ScopePricesWrite  = "prices:write"
+)
+
+// APIKeyScopes перечисляет все права API-ключей.
+var APIKeyScopes = []string{ScopeProductsRead, ScopeStockWrite, ScopePricesWrite}
+
+// APIKey — ключ интеграции поставщика. Сам ключ не хранится, только его хеш и префикс
+// для отображения.
+type APIKey struct {
+	ID         uuid.UUID
+	SupplierID uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
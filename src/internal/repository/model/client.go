@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// RoleClient — роль в токене доступа клиента магазина.
+const RoleClient = "client"
+
+// Назначение одноразовых токенов клиента.
+const (
+	ClientTokenVerifyEmail   = "verify_email"
+	ClientTokenResetPassword = "reset_password"
+)
+
+type ClientCredentials struct {
+	ClientID        uuid.UUID
+	Email           string
+	PasswordHash    string
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type ClientToken struct {
+	ID        uuid.UUID
+	ClientID  uuid.UUID
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	OrderStatusCreated   = "created"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusCancelled = "cancelled"
+)
+
+type Order struct {
+	ID        uuid.UUID
+	ClientID  uuid.UUID
+	Status    string
+	Total     float64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Items     []OrderItem
+}
+
+type OrderItem struct {
+	ID        uuid.UUID
+	OrderID   uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int
+	Price     float64
+}
@@ -0,0 +1,20 @@
+package model
+
+import "github.com/google/uuid"
+
+// Cursor указывает на последнюю строку предыдущей страницы. Списки упорядочены по паре
+// (ключ сортировки, id), поэтому следующая страница начинается строго после этой пары
+// и не сдвигается при вставке новых строк.
+type Cursor struct {
+	Sort  string
+	Desc  bool
+	Value string
+	ID    uuid.UUID
+}
+
+// Page задаёт размер страницы и курсор, после которого она начинается.
+// Без курсора возвращается первая страница.
+type Page struct {
+	Limit int
+	After *Cursor
+}
@@ -11,7 +11,55 @@ type Product struct {
 	Category       string
 	Price          float64
 	AvailableStock int
+	ReservedStock  int
 	LastUpdateDate time.Time
 	SupplierID     uuid.UUID
 	ImageID        *uuid.UUID
+	// Version растёт при каждом изменении товара, включая остатки.
+	Version int
+	// DeletedAt задан у удалённого товара. Такой товар виден только в списке
+	// с IncludeDeleted и окончательно удаляется после срока хранения.
+	DeletedAt *time.Time
+}
+
+// Поля, по которым можно сортировать список товаров.
+const (
+	ProductSortName           = "name"
+	ProductSortPrice          = "price"
+	ProductSortStock          = "stock"
+	ProductSortLastUpdateDate = "last_update_date"
+)
+
+var ProductSortFields = []string{ProductSortName, ProductSortPrice, ProductSortStock, ProductSortLastUpdateDate}
+
+// ProductFilter задаёт условия отбора, порядок и страницу списка товаров.
+// Пустые поля условий не ограничивают выборку.
+type ProductFilter struct {
+	Category     string
+	SupplierID   *uuid.UUID
+	MinPrice     *float64
+	MaxPrice     *float64
+	InStock      bool
+	UpdatedSince *time.Time
+	Sort         string
+	Desc         bool
+	// IncludeDeleted добавляет в выборку удалённые товары.
+	IncludeDeleted bool
+	Page
+}
+
+// ProductSearch задаёт поисковый запрос по названию и категории товара и страницу результатов.
+type ProductSearch struct {
+	Query string
+	Page
+}
+
+// ProductMatch — товар, найденный поиском. Rank объединяет полнотекстовую релевантность
+// и похожесть по триграммам. Подсветка — экранированный HTML, совпавшие слова в ней
+// обёрнуты в <mark>.
+type ProductMatch struct {
+	Product
+	Rank              float64
+	NameHighlight     string
+	CategoryHighlight string
 }
@@ -0,0 +1,12 @@
+package model
+
+// PurgeResult — число удалённых записей, стёртых окончательно после срока хранения.
+type PurgeResult struct {
+	Products  int
+	Suppliers int
+	Users     int
+}
+
+func (r PurgeResult) Total() int {
+	return r.Products + r.Suppliers + r.Users
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusReleased  = "released"
+	ReservationStatusExpired   = "expired"
+)
+
+type Reservation struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int
+	Status    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Expired — срок резерва истёк по часам БД. Заполняется только при чтении с блокировкой.
+	Expired bool
+}
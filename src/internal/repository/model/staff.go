@@ -0,0 +1,49 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Роли сотрудников.
+const (
+	RoleAdmin          = "admin"
+	RoleCatalogManager = "catalog_manager"
+	RoleWarehouse      = "warehouse"
+	RoleReadOnly       = "read_only"
+)
+
+// StaffRoles перечисляет все роли сотрудников.
+var StaffRoles = []string{RoleAdmin, RoleCatalogManager, RoleWarehouse, RoleReadOnly}
+
+type StaffUser struct {
+	ID           uuid.UUID
+	Login        string
+	PasswordHash string
+	Role         string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type RefreshToken struct {
+	ID          uuid.UUID
+	StaffUserID uuid.UUID
+	TokenHash   string
+	ExpiresAt   time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// TokenPair — выданные при входе токены доступа и обновления.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Principal описывает того, от чьего имени выполняется запрос.
+type Principal struct {
+	ID    uuid.UUID
+	Login string
+	Role  string
+}
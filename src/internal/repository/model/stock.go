@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	StockReasonSale       = "sale"
+	StockReasonRestock    = "restock"
+	StockReasonAdjustment = "adjustment"
+	StockReasonReturn     = "return"
+)
+
+type StockMovement struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	// ProductName — название товара на момент движения. Запись журнала хранит его сама,
+	// чтобы история оставалась читаемой после окончательного удаления товара.
+	ProductName string
+	Delta       int
+	Reason      string
+	Actor       string
+	CreatedAt   time.Time
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/google/uuid"
+	"time"
 )
 
 type Supplier struct {
@@ -9,4 +10,6 @@ type Supplier struct {
 	Name        string
 	AddressID   uuid.UUID
 	PhoneNumber string
+	Version     int
+	DeletedAt   *time.Time
 }
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type PoolStats struct {
+	MaxConns                int32
+	TotalConns              int32
+	AcquiredConns           int32
+	IdleConns               int32
+	ConstructingConns       int32
+	AcquireCount            int64
+	AcquireDuration         time.Duration
+	EmptyAcquireCount       int64
+	CanceledAcquireCount    int64
+	NewConnsCount           int64
+	MaxLifetimeDestroyCount int64
+	MaxIdleDestroyCount     int64
+}
+
+// Зависимости, которые проверяет проба готовности.
+const (
+	DependencyPostgres   = "postgres"
+	DependencyMigrations = "migrations"
+)
+
+// DependencyCheck — результат проверки одной зависимости.
+type DependencyCheck struct {
+	Name     string
+	Healthy  bool
+	Duration time.Duration
+	Err      error
+
+	// Для проверки миграций: применённая версия схемы и версия последней встроенной миграции.
+	Version         int64
+	ExpectedVersion int64
+}
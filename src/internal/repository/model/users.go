@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/google/uuid"
+	"log/slog"
 	"time"
 )
 
@@ -13,4 +14,41 @@ type User struct {
 	Gender           string
 	RegistrationDate time.Time
 	AddressID        uuid.UUID
+	Version          int
+	DeletedAt        *time.Time
+}
+
+// LogValue описывает клиента в журнале без персональных данных: имя и фамилия не пишутся,
+// а дата рождения скрывается журналом по имени атрибута.
+func (u User) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", u.ID.String()),
+		slog.Time("birthday", u.Birthday),
+		slog.String("gender", u.Gender),
+		slog.Time("registration_date", u.RegistrationDate),
+	)
+}
+
+// Genders перечисляет допустимые значения пола клиента.
+var Genders = []string{"Male", "Female", "Other"}
+
+// ClientFilter задаёт поиск клиентов. Query ищется в начале имени и фамилии без учёта регистра
+// и по похожести триграмм; остальные поля сужают выборку. Границы диапазонов дат включаются.
+type ClientFilter struct {
+	Query          string
+	BirthdayFrom   *time.Time
+	BirthdayTo     *time.Time
+	Gender         string
+	RegisteredFrom *time.Time
+	RegisteredTo   *time.Time
+	City           string
+	Country        string
+	Page
+}
+
+// UserMatch — клиент, найденный поиском. Rank выше у совпадений по началу имени или фамилии
+// и у более похожих; без поискового запроса он равен нулю.
+type UserMatch struct {
+	User
+	Rank float64
 }
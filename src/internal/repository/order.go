@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
+	"src/internal/repository/model"
+)
+
+type OrderPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewOrderPostgres(db *pgxpool.Pool) *OrderPostgres {
+	return &OrderPostgres{db: db}
+}
+
+func (r *OrderPostgres) CreateOrder(ctx context.Context, order model.Order) (model.Order, error) {
+	query := `
+		INSERT INTO orders (client_id, status, total, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, created_at, updated_at;
+	`
+
+	err := querier(ctx, r.db).QueryRow(ctx, query, order.ClientID, order.Status, order.Total).
+		Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("ошибка при создании заказа: %w", translateError(err))
+	}
+
+	return order, nil
+}
+
+func (r *OrderPostgres) AddOrderItem(ctx context.Context, item model.OrderItem) (uuid.UUID, error) {
+	query := `
+		INSERT INTO order_items (order_id, product_id, quantity, price)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id;
+	`
+
+	var itemID uuid.UUID
+	err := querier(ctx, r.db).QueryRow(ctx, query, item.OrderID, item.ProductID, item.Quantity, item.Price).Scan(&itemID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении позиции заказа: %w", translateError(err))
+	}
+
+	return itemID, nil
+}
+
+func (r *OrderPostgres) GetOrderByID(ctx context.Context, orderID uuid.UUID) (model.Order, error) {
+	query := `
+		SELECT id, client_id, status, total, created_at, updated_at
+		FROM orders
+		WHERE id = $1;
+	`
+
+	var order model.Order
+	err := querier(ctx, r.db).QueryRow(ctx, query, orderID).Scan(&order.ID, &order.ClientID, &order.Status,
+		&order.Total, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("ошибка при получении заказа: %w", translateError(err))
+	}
+
+	return order, nil
+}
+
+func (r *OrderPostgres) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]model.OrderItem, error) {
+	query := `
+		SELECT id, order_id, product_id, quantity, price
+		FROM order_items
+		WHERE order_id = $1
+		ORDER BY product_id;
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении позиций заказа: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var items []model.OrderItem
+	for rows.Next() {
+		var item model.OrderItem
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.Quantity, &item.Price); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return items, nil
+}
+
+// orderSortKey задаёт порядок заказов клиента: от новых к старым, затем по id.
+var orderSortKey = keyset{column: "created_at", cast: "timestamp"}
+
+// GetOrdersByClientID возвращает страницу заказов клиента, начиная с последних. Страница
+// содержит на одну строку больше лимита, если за ней есть ещё заказы.
+func (r *OrderPostgres) GetOrdersByClientID(ctx context.Context, clientID uuid.UUID, page model.Page) ([]model.Order, error) {
+	clause, args := orderSortKey.paginate(page, true, []string{"client_id = $1"}, []any{clientID})
+	query := `
+		SELECT id, client_id, status, total, created_at, updated_at
+		FROM orders` + clause + `;`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении заказов: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var orders []model.Order
+	for rows.Next() {
+		var order model.Order
+		if err := rows.Scan(&order.ID, &order.ClientID, &order.Status, &order.Total,
+			&order.CreatedAt, &order.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return orders, nil
+}
+
+// UpdateOrderStatus меняет статус заказа, только если текущий статус равен from.
+func (r *OrderPostgres) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, from, to string) error {
+	query := `
+		UPDATE orders
+		SET status = $1,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = $3;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, to, orderID, from)
+	if err != nil {
+		return fmt.Errorf("ошибка при изменении статуса заказа: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrConflict, "order.status_conflict")
+	}
+
+	return nil
+}
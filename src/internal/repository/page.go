@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"fmt"
+	"src/internal/repository/model"
+	"strings"
+)
+
+// keyset описывает ключ сортировки списка. Вторым ключом всегда служит id,
+// так что порядок строк однозначен и курсор указывает ровно на одну позицию.
+type keyset struct {
+	column string
+	// cast приводит текстовое значение курсора к типу столбца.
+	cast string
+}
+
+// paginate дополняет условия отбора условием курсора и возвращает WHERE, ORDER BY и LIMIT
+// страницы. Запрашивается на одну строку больше лимита, чтобы сервис узнал, есть ли
+// следующая страница.
+func (k keyset) paginate(page model.Page, desc bool, conditions []string, args []any) (string, []any) {
+	op, direction := ">", "ASC"
+	if desc {
+		op, direction = "<", "DESC"
+	}
+
+	if page.After != nil {
+		args = append(args, page.After.Value, page.After.ID)
+		conditions = append(conditions,
+			fmt.Sprintf("(%s, id) %s ($%d::%s, $%d)", k.column, op, len(args)-1, k.cast, len(args)))
+	}
+
+	args = append(args, page.Limit+1)
+	return fmt.Sprintf("%s\n\t\tORDER BY %s %s, id %s\n\t\tLIMIT $%d", whereClause(conditions),
+		k.column, direction, direction, len(args)), args
+}
+
+// whereClause склеивает условия через AND.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+}
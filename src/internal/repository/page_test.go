@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"src/internal/repository/model"
+	"testing"
+)
+
+func TestKeysetPaginate(t *testing.T) {
+	id := uuid.New()
+	price := keyset{column: "price", cast: "numeric"}
+
+	tests := []struct {
+		name       string
+		key        keyset
+		page       model.Page
+		desc       bool
+		conditions []string
+		args       []any
+		wantClause string
+		wantArgs   []any
+	}{
+		{
+			name:       "first page",
+			key:        price,
+			page:       model.Page{Limit: 20},
+			wantClause: "\n\t\tORDER BY price ASC, id ASC\n\t\tLIMIT $1",
+			wantArgs:   []any{21},
+		},
+		{
+			name:       "first page descending",
+			key:        price,
+			page:       model.Page{Limit: 20},
+			desc:       true,
+			wantClause: "\n\t\tORDER BY price DESC, id DESC\n\t\tLIMIT $1",
+			wantArgs:   []any{21},
+		},
+		{
+			name: "next page",
+			key:  price,
+			page: model.Page{Limit: 5, After: &model.Cursor{Sort: "price", Value: "10.5", ID: id}},
+			wantClause: "\n\t\tWHERE (price, id) > ($1::numeric, $2)" +
+				"\n\t\tORDER BY price ASC, id ASC\n\t\tLIMIT $3",
+			wantArgs: []any{"10.5", id, 6},
+		},
+		{
+			name: "next page descending",
+			key:  price,
+			page: model.Page{Limit: 5, After: &model.Cursor{Sort: "price", Desc: true, Value: "10.5", ID: id}},
+			desc: true,
+			wantClause: "\n\t\tWHERE (price, id) < ($1::numeric, $2)" +
+				"\n\t\tORDER BY price DESC, id DESC\n\t\tLIMIT $3",
+			wantArgs: []any{"10.5", id, 6},
+		},
+		{
+			name:       "filters without cursor",
+			key:        price,
+			page:       model.Page{Limit: 20},
+			conditions: []string{"category = $1", "deleted_at IS NULL"},
+			args:       []any{"Посуда"},
+			wantClause: "\n\t\tWHERE category = $1 AND deleted_at IS NULL" +
+				"\n\t\tORDER BY price ASC, id ASC\n\t\tLIMIT $2",
+			wantArgs: []any{"Посуда", 21},
+		},
+		{
+			name:       "filters and cursor",
+			key:        keyset{column: "last_update_date", cast: "timestamp"},
+			page:       model.Page{Limit: 10, After: &model.Cursor{Value: "2026-01-01T00:00:00Z", ID: id}},
+			conditions: []string{"category = $1"},
+			args:       []any{"Посуда"},
+			wantClause: "\n\t\tWHERE category = $1 AND (last_update_date, id) > ($2::timestamp, $3)" +
+				"\n\t\tORDER BY last_update_date ASC, id ASC\n\t\tLIMIT $4",
+			wantArgs: []any{"Посуда", "2026-01-01T00:00:00Z", id, 11},
+		},
+		{
+			// Значение курсора передаётся параметром и не может изменить текст запроса.
+			name: "tampered cursor value",
+			key:  price,
+			page: model.Page{Limit: 1, After: &model.Cursor{Value: "0) OR (1=1", ID: id}},
+			wantClause: "\n\t\tWHERE (price, id) > ($1::numeric, $2)" +
+				"\n\t\tORDER BY price ASC, id ASC\n\t\tLIMIT $3",
+			wantArgs: []any{"0) OR (1=1", id, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, args := tt.key.paginate(tt.page, tt.desc, tt.conditions, tt.args)
+
+			if clause != tt.wantClause {
+				t.Fatalf("clause = %q, want %q", clause, tt.wantClause)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestWhereClause(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		want       string
+	}{
+		{name: "no conditions", want: ""},
+		{name: "one condition", conditions: []string{"a = $1"}, want: "\n\t\tWHERE a = $1"},
+		{name: "several conditions", conditions: []string{"a = $1", "b = $2"}, want: "\n\t\tWHERE a = $1 AND b = $2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := whereClause(tt.conditions); got != tt.want {
+				t.Fatalf("whereClause = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,17 +2,21 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
 	"src/internal/repository/model"
+	"time"
 )
 
 type ProductPostgres struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
 }
 
-func NewProductPostgres(db *pgx.Conn) *ProductPostgres {
+func NewProductPostgres(db *pgxpool.Pool) *ProductPostgres {
 	return &ProductPostgres{db: db}
 }
 
@@ -24,57 +28,174 @@ func (r *ProductPostgres) CreateProduct(ctx context.Context, product model.Produ
 	`
 
 	var productID uuid.UUID
-	err := r.db.QueryRow(ctx, query, product.Name, product.Category, product.Price,
+	err := querier(ctx, r.db).QueryRow(ctx, query, product.Name, product.Category, product.Price,
 		product.AvailableStock, product.SupplierID, product.ImageID).Scan(&productID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении товара: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении товара: %w", translateError(err))
 	}
 
 	return productID, nil
 }
 
-func (r *ProductPostgres) ReduceStock(ctx context.Context, productID uuid.UUID, quantity int) error {
+// ChangeStock изменяет остаток товара на delta и возвращает новый остаток.
+// Остаток не может стать меньше зарезервированного количества. Если version больше нуля,
+// остаток меняется, только пока товар не менялся с этой версии.
+func (r *ProductPostgres) ChangeStock(ctx context.Context, productID uuid.UUID, delta, version int) (int, error) {
 	query := `
 		UPDATE product 
-		SET available_stock = available_stock - $1,
-		    last_update_date = CURRENT_TIMESTAMP
-		WHERE id = $2 AND available_stock >= $1;
+		SET available_stock = available_stock + $1,
+		    last_update_date = CURRENT_TIMESTAMP,
+		    version = version + 1
+		WHERE id = $2 AND ($3 = 0 OR version = $3) AND deleted_at IS NULL AND available_stock + $1 >= reserved_stock
+		RETURNING available_stock;
 	`
 
-	result, err := r.db.Exec(ctx, query, quantity, productID)
+	var stock int
+	err := querier(ctx, r.db).QueryRow(ctx, query, delta, productID, version).Scan(&stock)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("не удалось изменить количество товара: %w", r.stockError(ctx, productID, version))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при изменении количества товара: %w", translateError(err))
+	}
+
+	return stock, nil
+}
+
+// ReturnStock возвращает товар на склад и возвращает новый остаток. В отличие от ChangeStock
+// товар может быть удалён: отменённый заказ возвращает на склад и товары, снятые с продажи
+// после оформления заказа.
+func (r *ProductPostgres) ReturnStock(ctx context.Context, productID uuid.UUID, quantity int) (int, error) {
+	query := `
+		UPDATE product
+		SET available_stock = available_stock + $1,
+		    last_update_date = CURRENT_TIMESTAMP,
+		    version = version + 1
+		WHERE id = $2
+		RETURNING available_stock;
+	`
+
+	var stock int
+	err := querier(ctx, r.db).QueryRow(ctx, query, quantity, productID).Scan(&stock)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.New(domain.ErrNotFound, "product.not_found")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при возврате товара на склад: %w", translateError(err))
+	}
+
+	return stock, nil
+}
+
+func (r *ProductPostgres) ReserveStock(ctx context.Context, productID uuid.UUID, quantity int) error {
+	query := `
+		UPDATE product 
+		SET reserved_stock = reserved_stock + $1,
+		    version = version + 1
+		WHERE id = $2 AND deleted_at IS NULL AND available_stock - reserved_stock >= $1;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, quantity, productID)
 	if err != nil {
-		return fmt.Errorf("ошибка при уменьшении количества товара: %w", err)
+		return fmt.Errorf("ошибка при резервировании товара: %w", translateError(err))
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("не удалось уменьшить количество товара: недостаточно на складе или неверный ID")
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("не удалось зарезервировать товар: %w", r.stockError(ctx, productID, 0))
 	}
 
 	return nil
 }
 
+func (r *ProductPostgres) ReleaseStock(ctx context.Context, productID uuid.UUID, quantity int) error {
+	query := `
+		UPDATE product 
+		SET reserved_stock = reserved_stock - $1,
+		    version = version + 1
+		WHERE id = $2 AND reserved_stock >= $1;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, quantity, productID)
+	if err != nil {
+		return fmt.Errorf("ошибка при снятии резерва товара: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrConflict, "stock.release_conflict")
+	}
+
+	return nil
+}
+
+// stockError объясняет, почему условное изменение остатка не затронуло ни одной строки:
+// товара нет (или он удалён), его версия уже не version или на складе недостаточно
+// свободного количества. Версия проверяется, только если version больше нуля.
+func (r *ProductPostgres) stockError(ctx context.Context, productID uuid.UUID, version int) error {
+	query := `SELECT version FROM product WHERE id = $1 AND deleted_at IS NULL;`
+
+	var current int
+	err := querier(ctx, r.db).QueryRow(ctx, query, productID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.New(domain.ErrNotFound, "product.not_found")
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка при проверке товара: %w", translateError(err))
+	}
+
+	if version > 0 && current != version {
+		return domain.New(domain.ErrVersionMismatch, "error.version_mismatch")
+	}
+
+	return domain.ErrInsufficientStock
+}
+
 func (r *ProductPostgres) GetProductById(ctx context.Context, productID uuid.UUID) (model.Product, error) {
 	query := `
-		SELECT * FROM product 
-		WHERE id = $1;
+		SELECT id, name, category, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, version, deleted_at
+		FROM product 
+		WHERE id = $1 AND deleted_at IS NULL;
 	`
 	var product model.Product
-	err := r.db.QueryRow(ctx, query, productID).Scan(&product.ID, &product.Name, &product.Category, &product.Price,
-		&product.AvailableStock, &product.LastUpdateDate, &product.SupplierID, &product.ImageID)
+	err := querier(ctx, r.db).QueryRow(ctx, query, productID).Scan(&product.ID, &product.Name, &product.Category, &product.Price,
+		&product.AvailableStock, &product.ReservedStock, &product.LastUpdateDate, &product.SupplierID, &product.ImageID, &product.Version, &product.DeletedAt)
 	if err != nil {
-		return model.Product{}, fmt.Errorf("ошибка при получении товара: %w", err)
+		return model.Product{}, fmt.Errorf("ошибка при получении товара: %w", translateError(err))
 	}
 
 	return product, nil
 }
 
-func (r *ProductPostgres) GetProductList(ctx context.Context) ([]model.Product, error) {
-	query := `SELECT * FROM product;`
+// productSortKeys сопоставляет поля сортировки со столбцами; в запрос попадают только они.
+var productSortKeys = map[string]keyset{
+	model.ProductSortName:           {column: "name", cast: "text"},
+	model.ProductSortPrice:          {column: "price", cast: "numeric"},
+	model.ProductSortStock:          {column: "available_stock", cast: "integer"},
+	model.ProductSortLastUpdateDate: {column: "last_update_date", cast: "timestamp"},
+}
+
+// GetProductList возвращает страницу товаров по фильтру и общее число подходящих товаров.
+// Страница содержит на одну строку больше лимита, если за ней есть ещё товары.
+func (r *ProductPostgres) GetProductList(ctx context.Context, filter model.ProductFilter) ([]model.Product, int, error) {
+	conditions, args := productFilterConditions(filter)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM product` + whereClause(conditions)
+	if err := querier(ctx, r.db).QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка при подсчёте товаров: %w", translateError(err))
+	}
+
+	key, ok := productSortKeys[filter.Sort]
+	if !ok {
+		key = productSortKeys[model.ProductSortName]
+	}
+	clause, args := key.paginate(filter.Page, filter.Desc, conditions, args)
+	query := `
+		SELECT id, name, category, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, version, deleted_at
+		FROM product` + clause + `;`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении товаров: %w", err)
+		return nil, 0, fmt.Errorf("ошибка при получении товаров: %w", translateError(err))
 	}
 	defer rows.Close()
 
@@ -82,25 +203,271 @@ func (r *ProductPostgres) GetProductList(ctx context.Context) ([]model.Product,
 	for rows.Next() {
 		var product model.Product
 		if err := rows.Scan(&product.ID, &product.Name, &product.Category, &product.Price,
-			&product.AvailableStock, &product.LastUpdateDate, &product.SupplierID, &product.ImageID,
+			&product.AvailableStock, &product.ReservedStock, &product.LastUpdateDate, &product.SupplierID, &product.ImageID, &product.Version,
+			&product.DeletedAt,
 		); err != nil {
-			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+			return nil, 0, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
 		}
 		products = append(products, product)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка при обработке результатов: %w", err)
+		return nil, 0, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return products, total, nil
+}
+
+// productFilterConditions собирает условия отбора из заданных полей фильтра.
+func productFilterConditions(filter model.ProductFilter) ([]string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Category != "" {
+		add("category = $%d", filter.Category)
+	}
+	if filter.SupplierID != nil {
+		add("supplier_id = $%d", *filter.SupplierID)
+	}
+	if filter.MinPrice != nil {
+		add("price >= $%d", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		add("price <= $%d", *filter.MaxPrice)
+	}
+	if filter.UpdatedSince != nil {
+		// Без приведения к timestamptz pgx отбросил бы смещение момента. last_update_date
+		// записывается в часовом поясе сессии, в нём же Postgres и сравнивает.
+		add("last_update_date >= $%d::timestamptz", *filter.UpdatedSince)
+	}
+	if filter.InStock {
+		conditions = append(conditions, "available_stock > reserved_stock")
+	}
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	return conditions, args
+}
+
+// productSearchKey задаёт порядок результатов поиска: по убыванию релевантности, затем по id.
+var productSearchKey = keyset{column: "rank", cast: "float8"}
+
+// SearchProducts ищет товары по названию и категории. Полнотекстовый запрос строится
+// в русской и английской конфигурациях, а триграммы находят слова с опечатками.
+// Подсветка (product_headline) строится только для строк страницы и возвращается как
+// экранированный HTML. Страница содержит на одну строку больше лимита, если за ней
+// есть ещё результаты.
+func (r *ProductPostgres) SearchProducts(ctx context.Context, search model.ProductSearch) ([]model.ProductMatch, error) {
+	clause, args := productSearchKey.paginate(search.Page, true, nil, []any{search.Query})
+	query := `
+		WITH search AS (
+			SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query
+		), found AS (
+			SELECT p.id, p.name, p.category, p.price, p.available_stock, p.reserved_stock,
+				p.last_update_date, p.supplier_id, p.image_id, p.version, p.deleted_at,
+				(ts_rank(p.search_vector, s.query) + GREATEST(similarity(p.name, $1), similarity(p.category, $1)))::float8 AS rank
+			FROM product p, search s
+			WHERE p.deleted_at IS NULL AND (p.search_vector @@ s.query OR p.name % $1 OR p.category % $1)
+		), page AS (
+			SELECT * FROM found` + clause + `
+		)
+		SELECT page.id, page.name, page.category, page.price, page.available_stock, page.reserved_stock,
+			page.last_update_date, page.supplier_id, page.image_id, page.version, page.deleted_at, page.rank,
+			product_headline(page.name, $1), product_headline(page.category, $1)
+		FROM page
+		ORDER BY page.rank DESC, page.id DESC;
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при поиске товаров: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var matches []model.ProductMatch
+	for rows.Next() {
+		var match model.ProductMatch
+		if err := rows.Scan(&match.ID, &match.Name, &match.Category, &match.Price,
+			&match.AvailableStock, &match.ReservedStock, &match.LastUpdateDate, &match.SupplierID, &match.ImageID, &match.Version, &match.DeletedAt,
+			&match.Rank, &match.NameHighlight, &match.CategoryHighlight,
+		); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return matches, nil
+}
+
+func (r *ProductPostgres) GetProductsBySupplierID(ctx context.Context, supplierID uuid.UUID) ([]model.Product, error) {
+	query := `
+		SELECT id, name, category, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, version, deleted_at
+		FROM product
+		WHERE supplier_id = $1 AND deleted_at IS NULL;
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении товаров поставщика: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var products []model.Product
+	for rows.Next() {
+		var product model.Product
+		if err := rows.Scan(&product.ID, &product.Name, &product.Category, &product.Price,
+			&product.AvailableStock, &product.ReservedStock, &product.LastUpdateDate, &product.SupplierID, &product.ImageID, &product.Version,
+			&product.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
 	}
 
 	return products, nil
 }
 
-func (r *ProductPostgres) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
-	query := `DELETE FROM product WHERE id = $1;`
-	_, err := r.db.Exec(ctx, query, productID)
+// LockSupplierProduct блокирует строку товара до конца текущей транзакции, если товар
+// принадлежит поставщику. Товар другого поставщика считается не найденным.
+func (r *ProductPostgres) LockSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID) error {
+	query := `SELECT id FROM product WHERE id = $1 AND supplier_id = $2 AND deleted_at IS NULL FOR UPDATE;`
+
+	var id uuid.UUID
+	err := querier(ctx, r.db).QueryRow(ctx, query, productID, supplierID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.New(domain.ErrNotFound, "product.not_found")
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка при блокировке товара: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// UpdateProductPrice меняет цену товара, если он не менялся с версии version.
+func (r *ProductPostgres) UpdateProductPrice(ctx context.Context, productID uuid.UUID, price float64, version int) error {
+	query := `
+		UPDATE product
+		SET price = $1,
+		    last_update_date = CURRENT_TIMESTAMP,
+		    version = version + 1
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, price, productID, version)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении товара: %w", err)
+		return fmt.Errorf("ошибка при изменении цены товара: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return versionError(ctx, r.db, "product", productID, "product.not_found")
 	}
 	return nil
 }
+
+// UpdateProduct изменяет описание товара, если его версия совпадает с product.Version,
+// и возвращает новое состояние. Остатки меняются только через движения склада,
+// поэтому здесь не затрагиваются.
+func (r *ProductPostgres) UpdateProduct(ctx context.Context, product model.Product) (model.Product, error) {
+	query := `
+		UPDATE product
+		SET name = $1,
+		    category = $2,
+		    price = $3,
+		    supplier_id = $4,
+		    last_update_date = CURRENT_TIMESTAMP,
+		    version = version + 1
+		WHERE id = $5 AND version = $6 AND deleted_at IS NULL
+		RETURNING id, name, category, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, version, deleted_at;
+	`
+
+	var updated model.Product
+	err := querier(ctx, r.db).QueryRow(ctx, query, product.Name, product.Category, product.Price, product.SupplierID,
+		product.ID, product.Version).Scan(
+		&updated.ID, &updated.Name, &updated.Category, &updated.Price,
+		&updated.AvailableStock, &updated.ReservedStock, &updated.LastUpdateDate, &updated.SupplierID, &updated.ImageID, &updated.Version, &updated.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Product{}, versionError(ctx, r.db, "product", product.ID, "product.not_found")
+	}
+	if err != nil {
+		return model.Product{}, fmt.Errorf("ошибка при изменении товара: %w", translateError(err))
+	}
+
+	return updated, nil
+}
+
+// DeleteProduct помечает товар удалённым, если его версия совпадает с version.
+// Строка остаётся в таблице до восстановления или окончательной очистки.
+func (r *ProductPostgres) DeleteProduct(ctx context.Context, productID uuid.UUID, version int) error {
+	query := `
+		UPDATE product
+		SET deleted_at = CURRENT_TIMESTAMP,
+		    version = version + 1
+		WHERE id = $1 AND version = $2 AND deleted_at IS NULL;
+	`
+	result, err := querier(ctx, r.db).Exec(ctx, query, productID, version)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении товара: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return versionError(ctx, r.db, "product", productID, "product.not_found")
+	}
+	return nil
+}
+
+// RestoreProduct снимает с товара отметку об удалении и возвращает его новое состояние.
+func (r *ProductPostgres) RestoreProduct(ctx context.Context, productID uuid.UUID) (model.Product, error) {
+	query := `
+		UPDATE product
+		SET deleted_at = NULL,
+		    version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, name, category, price, available_stock, reserved_stock, last_update_date, supplier_id, image_id, version, deleted_at;
+	`
+
+	var product model.Product
+	err := querier(ctx, r.db).QueryRow(ctx, query, productID).Scan(&product.ID, &product.Name, &product.Category, &product.Price,
+		&product.AvailableStock, &product.ReservedStock, &product.LastUpdateDate, &product.SupplierID, &product.ImageID, &product.Version,
+		&product.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Product{}, restoreError(ctx, r.db, "product", productID, "product.not_found", "product.not_deleted")
+	}
+	if err != nil {
+		return model.Product{}, fmt.Errorf("ошибка при восстановлении товара: %w", translateError(err))
+	}
+
+	return product, nil
+}
+
+// PurgeProducts окончательно удаляет товары, удалённые раньше чем retention назад,
+// и возвращает их количество. Товары из заказов остаются в таблице для истории заказов.
+// Записи журнала движения сохраняются: ссылка в них обнуляется, название товара остаётся.
+func (r *ProductPostgres) PurgeProducts(ctx context.Context, retention time.Duration) (int, error) {
+	query := `
+		DELETE FROM product p
+		WHERE p.deleted_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+		  AND NOT EXISTS (SELECT 1 FROM order_items i WHERE i.product_id = p.id);
+	`
+	result, err := querier(ctx, r.db).Exec(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("ошибка при очистке удалённых товаров: %w", translateError(err))
+	}
+
+	return int(result.RowsAffected()), nil
+}
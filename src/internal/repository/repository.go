@@ -3,38 +3,58 @@ package repository
 import (
 	"context"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"src/internal/repository/model"
+	"time"
 )
 
 type User interface {
 	AddUser(ctx context.Context, user model.User) (uuid.UUID, error)
+	GetUserByID(ctx context.Context, userID uuid.UUID) (model.User, error)
+	UpdateUser(ctx context.Context, user model.User) (model.User, error)
 	GetAddressIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
-	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	DeleteUser(ctx context.Context, userID uuid.UUID, version int) error
 	GetUserNameSurname(ctx context.Context, name, surname string) ([]model.User, error)
-	GetUserList(ctx context.Context, limit, offset int) ([]model.User, error)
+	GetUserList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.User, error)
+	SearchUsers(ctx context.Context, filter model.ClientFilter) ([]model.UserMatch, error)
+	RestoreUser(ctx context.Context, userID uuid.UUID) (model.User, error)
+	PurgeUsers(ctx context.Context, retention time.Duration) (int, []uuid.UUID, error)
 }
 
 type Address interface {
 	CreateAddress(ctx context.Context, address model.Address) (uuid.UUID, error)
-	DeleteAddress(ctx context.Context, addressID uuid.UUID) error
-	UpdateAddress(ctx context.Context, address model.Address) error
+	GetAddressByID(ctx context.Context, addressID uuid.UUID) (model.Address, error)
+	DeleteAddresses(ctx context.Context, addressIDs []uuid.UUID) error
+	UpdateAddress(ctx context.Context, address model.Address) (model.Address, error)
 }
 
 type Supplier interface {
 	AddSupplier(ctx context.Context, supplier model.Supplier) (uuid.UUID, error)
+	UpdateSupplier(ctx context.Context, supplier model.Supplier) (model.Supplier, error)
 	GetAddressIDBySupplierID(ctx context.Context, supplierID uuid.UUID) (uuid.UUID, error)
-	DeleteSupplier(ctx context.Context, userID uuid.UUID) error
-	GetSupplierList(ctx context.Context) ([]model.Supplier, error)
+	DeleteSupplier(ctx context.Context, supplierID uuid.UUID, version int) error
+	GetSupplierList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.Supplier, error)
 	GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error)
+	RestoreSupplier(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error)
+	PurgeSuppliers(ctx context.Context, retention time.Duration) (int, []uuid.UUID, error)
 }
 
 type Product interface {
 	CreateProduct(ctx context.Context, product model.Product) (uuid.UUID, error)
-	ReduceStock(ctx context.Context, productID uuid.UUID, quantity int) error
+	ChangeStock(ctx context.Context, productID uuid.UUID, delta, version int) (int, error)
+	ReturnStock(ctx context.Context, productID uuid.UUID, quantity int) (int, error)
+	ReserveStock(ctx context.Context, productID uuid.UUID, quantity int) error
+	ReleaseStock(ctx context.Context, productID uuid.UUID, quantity int) error
 	GetProductById(ctx context.Context, productID uuid.UUID) (model.Product, error)
-	GetProductList(ctx context.Context) ([]model.Product, error)
-	DeleteProduct(ctx context.Context, productID uuid.UUID) error
+	GetProductList(ctx context.Context, filter model.ProductFilter) ([]model.Product, int, error)
+	SearchProducts(ctx context.Context, search model.ProductSearch) ([]model.ProductMatch, error)
+	GetProductsBySupplierID(ctx context.Context, supplierID uuid.UUID) ([]model.Product, error)
+	LockSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID) error
+	UpdateProduct(ctx context.Context, product model.Product) (model.Product, error)
+	UpdateProductPrice(ctx context.Context, productID uuid.UUID, price float64, version int) error
+	DeleteProduct(ctx context.Context, productID uuid.UUID, version int) error
+	RestoreProduct(ctx context.Context, productID uuid.UUID) (model.Product, error)
+	PurgeProducts(ctx context.Context, retention time.Duration) (int, error)
 }
 
 type Image interface {
@@ -48,20 +68,95 @@ type Image interface {
 	GetImageById(ctx context.Context, imageID uuid.UUID) (model.Image, error)
 }
 
+type Stock interface {
+	AddStockMovement(ctx context.Context, movement model.StockMovement) (model.StockMovement, error)
+	GetStockMovements(ctx context.Context, productID uuid.UUID, page model.Page) ([]model.StockMovement, error)
+}
+
+type Reservation interface {
+	CreateReservation(ctx context.Context, reservation model.Reservation, ttl time.Duration) (model.Reservation, error)
+	GetReservationByID(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error)
+	GetReservationForUpdate(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error)
+	UpdateReservationStatus(ctx context.Context, reservationID uuid.UUID, from, to string) error
+	GetExpiredReservations(ctx context.Context, limit int) ([]model.Reservation, error)
+}
+
+type Order interface {
+	CreateOrder(ctx context.Context, order model.Order) (model.Order, error)
+	AddOrderItem(ctx context.Context, item model.OrderItem) (uuid.UUID, error)
+	GetOrderByID(ctx context.Context, orderID uuid.UUID) (model.Order, error)
+	GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]model.OrderItem, error)
+	GetOrdersByClientID(ctx context.Context, clientID uuid.UUID, page model.Page) ([]model.Order, error)
+	UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, from, to string) error
+}
+
+type Staff interface {
+	CreateStaffUser(ctx context.Context, user model.StaffUser) (uuid.UUID, error)
+	GetStaffUserByLogin(ctx context.Context, login string) (model.StaffUser, error)
+	GetStaffUserByID(ctx context.Context, userID uuid.UUID) (model.StaffUser, error)
+	CreateRefreshToken(ctx context.Context, token model.RefreshToken) error
+	GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (model.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenID uuid.UUID) error
+}
+
+type ClientAccount interface {
+	CreateClientCredentials(ctx context.Context, credentials model.ClientCredentials) error
+	GetClientCredentialsByEmail(ctx context.Context, email string) (model.ClientCredentials, error)
+	SetClientPassword(ctx context.Context, clientID uuid.UUID, passwordHash string) error
+	MarkClientEmailVerified(ctx context.Context, clientID uuid.UUID) error
+	CreateClientToken(ctx context.Context, token model.ClientToken) error
+	GetClientTokenForUpdate(ctx context.Context, purpose, tokenHash string) (model.ClientToken, error)
+	MarkClientTokenUsed(ctx context.Context, tokenID uuid.UUID) error
+}
+
+type APIKey interface {
+	CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error)
+	GetSupplierAPIKeys(ctx context.Context, supplierID uuid.UUID, page model.Page) ([]model.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error)
+	GetSupplierAPIKeyForUpdate(ctx context.Context, supplierID, keyID uuid.UUID) (model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error
+	TouchAPIKey(ctx context.Context, keyID uuid.UUID) error
+}
+
+type Transaction interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type System interface {
+	PoolStats() model.PoolStats
+	Ping(ctx context.Context) error
+}
+
 type Repository struct {
 	User
 	Address
 	Supplier
 	Product
 	Image
+	Order
+	Stock
+	Reservation
+	Staff
+	ClientAccount
+	APIKey
+	System
+	Transaction
 }
 
-func NewRepositore(db *pgx.Conn) *Repository {
+func NewRepositore(db *pgxpool.Pool) *Repository {
 	return &Repository{
-		User:     NewUserPostgres(db),
-		Address:  NewAddressPostgres(db),
-		Supplier: NewSupplierPostgres(db),
-		Product:  NewProductPostgres(db),
-		Image:    NewImagePostgres(db),
+		User:          NewUserPostgres(db),
+		Address:       NewAddressPostgres(db),
+		Supplier:      NewSupplierPostgres(db),
+		Product:       NewProductPostgres(db),
+		Image:         NewImagePostgres(db),
+		Order:         NewOrderPostgres(db),
+		Stock:         NewStockPostgres(db),
+		Reservation:   NewReservationPostgres(db),
+		Staff:         NewStaffPostgres(db),
+		ClientAccount: NewClientAccountPostgres(db),
+		APIKey:        NewAPIKeyPostgres(db),
+		System:        NewSystemPostgres(db),
+		Transaction:   NewTransactionPostgres(db),
 	}
 }
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
+	"src/internal/repository/model"
+	"time"
+)
+
+type ReservationPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewReservationPostgres(db *pgxpool.Pool) *ReservationPostgres {
+	return &ReservationPostgres{db: db}
+}
+
+func (r *ReservationPostgres) CreateReservation(ctx context.Context, reservation model.Reservation, ttl time.Duration) (model.Reservation, error) {
+	query := `
+		INSERT INTO stock_reservations (product_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, expires_at, created_at, updated_at;
+	`
+
+	err := querier(ctx, r.db).QueryRow(ctx, query, reservation.ProductID, reservation.Quantity, reservation.Status,
+		ttl.Seconds()).Scan(&reservation.ID, &reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt)
+	if err != nil {
+		return model.Reservation{}, fmt.Errorf("ошибка при создании резерва: %w", translateError(err))
+	}
+
+	return reservation, nil
+}
+
+func (r *ReservationPostgres) GetReservationByID(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error) {
+	query := `
+		SELECT id, product_id, quantity, status, expires_at, created_at, updated_at
+		FROM stock_reservations
+		WHERE id = $1;
+	`
+
+	var reservation model.Reservation
+	err := querier(ctx, r.db).QueryRow(ctx, query, reservationID).Scan(&reservation.ID, &reservation.ProductID,
+		&reservation.Quantity, &reservation.Status, &reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt)
+	if err != nil {
+		return model.Reservation{}, fmt.Errorf("ошибка при получении резерва: %w", translateError(err))
+	}
+
+	return reservation, nil
+}
+
+// GetReservationForUpdate блокирует строку резерва до конца текущей транзакции. Истечение
+// срока проверяется по часам БД, которыми срок и назначался.
+func (r *ReservationPostgres) GetReservationForUpdate(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error) {
+	query := `
+		SELECT id, product_id, quantity, status, expires_at, created_at, updated_at, expires_at <= CURRENT_TIMESTAMP
+		FROM stock_reservations
+		WHERE id = $1
+		FOR UPDATE;
+	`
+
+	var reservation model.Reservation
+	err := querier(ctx, r.db).QueryRow(ctx, query, reservationID).Scan(&reservation.ID, &reservation.ProductID,
+		&reservation.Quantity, &reservation.Status, &reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt, &reservation.Expired)
+	if err != nil {
+		return model.Reservation{}, fmt.Errorf("ошибка при получении резерва: %w", translateError(err))
+	}
+
+	return reservation, nil
+}
+
+func (r *ReservationPostgres) UpdateReservationStatus(ctx context.Context, reservationID uuid.UUID, from, to string) error {
+	query := `
+		UPDATE stock_reservations
+		SET status = $1,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = $3;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, to, reservationID, from)
+	if err != nil {
+		return fmt.Errorf("ошибка при изменении статуса резерва: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrConflict, "reservation.status_conflict")
+	}
+
+	return nil
+}
+
+// GetExpiredReservations возвращает и блокирует истёкшие активные резервы.
+// Строки, заблокированные другим экземпляром, пропускаются.
+func (r *ReservationPostgres) GetExpiredReservations(ctx context.Context, limit int) ([]model.Reservation, error) {
+	query := `
+		SELECT id, product_id, quantity, status, expires_at, created_at, updated_at
+		FROM stock_reservations
+		WHERE status = 'active' AND expires_at < CURRENT_TIMESTAMP
+		ORDER BY expires_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED;
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении истёкших резервов: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var reservations []model.Reservation
+	for rows.Next() {
+		var reservation model.Reservation
+		if err := rows.Scan(&reservation.ID, &reservation.ProductID, &reservation.Quantity, &reservation.Status,
+			&reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return reservations, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/domain"
+	"src/internal/repository/model"
+)
+
+type StaffPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewStaffPostgres(db *pgxpool.Pool) *StaffPostgres {
+	return &StaffPostgres{db: db}
+}
+
+func (r *StaffPostgres) CreateStaffUser(ctx context.Context, user model.StaffUser) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	query := `
+		INSERT INTO staff_users (login, password_hash, role, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id;
+	`
+
+	err := querier(ctx, r.db).QueryRow(ctx, query, user.Login, user.PasswordHash, user.Role).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении сотрудника: %w", translateError(err))
+	}
+
+	return id, nil
+}
+
+func (r *StaffPostgres) GetStaffUserByLogin(ctx context.Context, login string) (model.StaffUser, error) {
+	query := `
+		SELECT id, login, password_hash, role, created_at, updated_at
+		FROM staff_users
+		WHERE login = $1;
+	`
+
+	var user model.StaffUser
+	err := querier(ctx, r.db).QueryRow(ctx, query, login).Scan(&user.ID, &user.Login, &user.PasswordHash,
+		&user.Role, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return model.StaffUser{}, fmt.Errorf("ошибка при получении сотрудника: %w", translateError(err))
+	}
+
+	return user, nil
+}
+
+func (r *StaffPostgres) GetStaffUserByID(ctx context.Context, userID uuid.UUID) (model.StaffUser, error) {
+	query := `
+		SELECT id, login, password_hash, role, created_at, updated_at
+		FROM staff_users
+		WHERE id = $1;
+	`
+
+	var user model.StaffUser
+	err := querier(ctx, r.db).QueryRow(ctx, query, userID).Scan(&user.ID, &user.Login, &user.PasswordHash,
+		&user.Role, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return model.StaffUser{}, fmt.Errorf("ошибка при получении сотрудника: %w", translateError(err))
+	}
+
+	return user, nil
+}
+
+func (r *StaffPostgres) CreateRefreshToken(ctx context.Context, token model.RefreshToken) error {
+	query := `
+		INSERT INTO staff_refresh_tokens (staff_user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP);
+	`
+
+	_, err := querier(ctx, r.db).Exec(ctx, query, token.StaffUserID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении токена обновления: %w", translateError(err))
+	}
+
+	return nil
+}
+
+// GetRefreshTokenForUpdate блокирует строку токена до конца текущей транзакции,
+// чтобы один токен нельзя было обменять дважды.
+func (r *StaffPostgres) GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	query := `
+		SELECT id, staff_user_id, token_hash, expires_at, revoked_at, created_at
+		FROM staff_refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE;
+	`
+
+	var token model.RefreshToken
+	err := querier(ctx, r.db).QueryRow(ctx, query, tokenHash).Scan(&token.ID, &token.StaffUserID, &token.TokenHash,
+		&token.ExpiresAt, &token.RevokedAt, &token.CreatedAt)
+	if err != nil {
+		return model.RefreshToken{}, fmt.Errorf("ошибка при получении токена обновления: %w", translateError(err))
+	}
+
+	return token, nil
+}
+
+func (r *StaffPostgres) RevokeRefreshToken(ctx context.Context, tokenID uuid.UUID) error {
+	query := `
+		UPDATE staff_refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND revoked_at IS NULL;
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, tokenID)
+	if err != nil {
+		return fmt.Errorf("ошибка при отзыве токена обновления: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.New(domain.ErrUnauthorized, "auth.invalid_refresh_token")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/repository/model"
+)
+
+type StockPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewStockPostgres(db *pgxpool.Pool) *StockPostgres {
+	return &StockPostgres{db: db}
+}
+
+// AddStockMovement записывает движение в журнал вместе с текущим названием товара.
+func (r *StockPostgres) AddStockMovement(ctx context.Context, movement model.StockMovement) (model.StockMovement, error) {
+	query := `
+		INSERT INTO stock_movements (product_id, product_name, delta, reason, actor, created_at)
+		SELECT id, name, $2, $3, $4, CURRENT_TIMESTAMP
+		FROM product
+		WHERE id = $1
+		RETURNING id, product_name, created_at;
+	`
+
+	err := querier(ctx, r.db).QueryRow(ctx, query, movement.ProductID, movement.Delta, movement.Reason, movement.Actor).
+		Scan(&movement.ID, &movement.ProductName, &movement.CreatedAt)
+	if err != nil {
+		return model.StockMovement{}, fmt.Errorf("ошибка при добавлении движения товара: %w", translateError(err))
+	}
+
+	return movement, nil
+}
+
+// stockMovementSortKey задаёт порядок журнала движения: от новых записей к старым, затем по id.
+var stockMovementSortKey = keyset{column: "created_at", cast: "timestamp"}
+
+// GetStockMovements возвращает страницу журнала движения товара, начиная с последних записей.
+// Страница содержит на одну строку больше лимита, если за ней есть ещё записи.
+func (r *StockPostgres) GetStockMovements(ctx context.Context, productID uuid.UUID, page model.Page) ([]model.StockMovement, error) {
+	clause, args := stockMovementSortKey.paginate(page, true, []string{"product_id = $1"}, []any{productID})
+	query := `
+		SELECT id, product_id, product_name, delta, reason, actor, created_at
+		FROM stock_movements` + clause + `;`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении движений товара: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var movements []model.StockMovement
+	for rows.Next() {
+		var movement model.StockMovement
+		if err := rows.Scan(&movement.ID, &movement.ProductID, &movement.ProductName, &movement.Delta, &movement.Reason,
+			&movement.Actor, &movement.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		movements = append(movements, movement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return movements, nil
+}
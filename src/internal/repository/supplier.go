@@ -2,17 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"src/internal/repository/model"
+	"time"
 )
 
 type SupplierPostgres struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
 }
 
-func NewSupplierPostgres(db *pgx.Conn) *SupplierPostgres {
+func NewSupplierPostgres(db *pgxpool.Pool) *SupplierPostgres {
 	return &SupplierPostgres{db: db}
 }
 
@@ -25,39 +28,132 @@ func (r *SupplierPostgres) AddSupplier(ctx context.Context, supplier model.Suppl
 		RETURNING id;
 	`
 
-	err := r.db.QueryRow(ctx, query, supplier.Name, supplier.AddressID, supplier.PhoneNumber).Scan(&id)
+	err := querier(ctx, r.db).QueryRow(ctx, query, supplier.Name, supplier.AddressID, supplier.PhoneNumber).Scan(&id)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении поставщика: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении поставщика: %w", translateError(err))
 	}
 
 	return id, nil
 }
 
+// UpdateSupplier изменяет название и телефон поставщика, если его версия совпадает
+// с supplier.Version, и возвращает новое состояние.
+func (r *SupplierPostgres) UpdateSupplier(ctx context.Context, supplier model.Supplier) (model.Supplier, error) {
+	query := `
+		UPDATE supplier
+		SET name = $1, phone_number = $2, version = version + 1
+		WHERE id = $3 AND version = $4 AND deleted_at IS NULL
+		RETURNING id, name, address_id, phone_number, version, deleted_at;
+	`
+
+	var updated model.Supplier
+	err := querier(ctx, r.db).QueryRow(ctx, query, supplier.Name, supplier.PhoneNumber, supplier.ID, supplier.Version).Scan(
+		&updated.ID, &updated.Name, &updated.AddressID, &updated.PhoneNumber, &updated.Version, &updated.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Supplier{}, versionError(ctx, r.db, "supplier", supplier.ID, "supplier.not_found")
+	}
+	if err != nil {
+		return model.Supplier{}, fmt.Errorf("ошибка при изменении поставщика: %w", translateError(err))
+	}
+
+	return updated, nil
+}
+
 func (r *SupplierPostgres) GetAddressIDBySupplierID(ctx context.Context, supplierID uuid.UUID) (uuid.UUID, error) {
 	var addressID uuid.UUID
-	query := `SELECT address_id FROM supplier WHERE id = $1;`
-	err := r.db.QueryRow(ctx, query, supplierID).Scan(&addressID)
+	query := `SELECT address_id FROM supplier WHERE id = $1 AND deleted_at IS NULL;`
+	err := querier(ctx, r.db).QueryRow(ctx, query, supplierID).Scan(&addressID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при получении address_id поставщика: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при получении address_id поставщика: %w", translateError(err))
 	}
 	return addressID, nil
 }
 
-func (r *SupplierPostgres) DeleteSupplier(ctx context.Context, supplierID uuid.UUID) error {
-	query := `DELETE FROM supplier WHERE id = $1;`
-	_, err := r.db.Exec(ctx, query, supplierID)
+// DeleteSupplier помечает поставщика удалённым, если его версия совпадает с version.
+// Товары поставщика сохраняют ссылку на него, а сам поставщик и его адрес остаются
+// в таблицах до восстановления или окончательной очистки.
+func (r *SupplierPostgres) DeleteSupplier(ctx context.Context, supplierID uuid.UUID, version int) error {
+	query := `
+		UPDATE supplier
+		SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND version = $2 AND deleted_at IS NULL;
+	`
+	result, err := querier(ctx, r.db).Exec(ctx, query, supplierID, version)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении поставщика: %w", err)
+		return fmt.Errorf("ошибка при удалении поставщика: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return versionError(ctx, r.db, "supplier", supplierID, "supplier.not_found")
 	}
 	return nil
 }
 
-func (r *SupplierPostgres) GetSupplierList(ctx context.Context) ([]model.Supplier, error) {
-	query := `SELECT * FROM supplier;`
+// RestoreSupplier снимает с поставщика отметку об удалении и возвращает его новое состояние.
+// Если его телефон успел занять другой поставщик, восстановление отклоняется.
+func (r *SupplierPostgres) RestoreSupplier(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error) {
+	query := `
+		UPDATE supplier
+		SET deleted_at = NULL, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, name, address_id, phone_number, version, deleted_at;
+	`
+
+	var supplier model.Supplier
+	err := querier(ctx, r.db).QueryRow(ctx, query, supplierID).Scan(&supplier.ID, &supplier.Name, &supplier.AddressID,
+		&supplier.PhoneNumber, &supplier.Version, &supplier.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Supplier{}, restoreError(ctx, r.db, "supplier", supplierID, "supplier.not_found", "supplier.not_deleted")
+	}
+	if err != nil {
+		return model.Supplier{}, fmt.Errorf("ошибка при восстановлении поставщика: %w", translateError(err))
+	}
+
+	return supplier, nil
+}
+
+// PurgeSuppliers окончательно удаляет поставщиков, удалённых раньше чем retention назад,
+// и возвращает их количество и id их адресов. Поставщик, на которого ещё ссылаются товары,
+// остаётся в таблице.
+func (r *SupplierPostgres) PurgeSuppliers(ctx context.Context, retention time.Duration) (int, []uuid.UUID, error) {
+	query := `
+		DELETE FROM supplier s
+		WHERE s.deleted_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+		  AND NOT EXISTS (SELECT 1 FROM product p WHERE p.supplier_id = s.id)
+		RETURNING s.address_id;
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, nil, fmt.Errorf("ошибка при очистке удалённых поставщиков: %w", translateError(err))
+	}
+
+	purged, addressIDs, err := scanAddressIDs(rows)
+	if err != nil {
+		return 0, nil, fmt.Errorf("ошибка при очистке удалённых поставщиков: %w", err)
+	}
+
+	return purged, addressIDs, nil
+}
+
+// supplierSortKey задаёт порядок списка поставщиков: по названию, затем по id.
+var supplierSortKey = keyset{column: "name", cast: "text"}
 
-	rows, err := r.db.Query(ctx, query)
+// GetSupplierList возвращает страницу поставщиков; удалённые поставщики попадают в неё только
+// с includeDeleted. Страница содержит на одну строку больше лимита, если за ней есть ещё поставщики.
+func (r *SupplierPostgres) GetSupplierList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.Supplier, error) {
+	var conditions []string
+	if !includeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	clause, args := supplierSortKey.paginate(page, false, conditions, nil)
+	query := `
+		SELECT id, name, address_id, phone_number, version, deleted_at
+		FROM supplier` + clause + `;`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении поставщиков: %w", err)
+		return nil, fmt.Errorf("ошибка при получении поставщиков: %w", translateError(err))
 	}
 	defer rows.Close()
 
@@ -65,27 +161,32 @@ func (r *SupplierPostgres) GetSupplierList(ctx context.Context) ([]model.Supplie
 	for rows.Next() {
 		var supplier model.Supplier
 		if err := rows.Scan(
-			&supplier.ID, &supplier.Name, &supplier.AddressID, &supplier.PhoneNumber,
+			&supplier.ID, &supplier.Name, &supplier.AddressID, &supplier.PhoneNumber, &supplier.Version, &supplier.DeletedAt,
 		); err != nil {
-			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
 		}
 		suppliers = append(suppliers, supplier)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка при обработке результатов: %w", err)
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
 	}
 
 	return suppliers, nil
 }
 
 func (r *SupplierPostgres) GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error) {
-	query := `SELECT * FROM supplier WHERE id = $1;`
+	query := `
+		SELECT id, name, address_id, phone_number, version, deleted_at
+		FROM supplier
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
 
 	var supplier model.Supplier
-	err := r.db.QueryRow(ctx, query, supplierID).Scan(&supplier.ID, &supplier.Name, &supplier.AddressID, &supplier.PhoneNumber)
+	err := querier(ctx, r.db).QueryRow(ctx, query, supplierID).Scan(&supplier.ID, &supplier.Name, &supplier.AddressID, &supplier.PhoneNumber,
+		&supplier.Version, &supplier.DeletedAt)
 	if err != nil {
-		return model.Supplier{}, fmt.Errorf("ошибка при получении поставщика: %w", err)
+		return model.Supplier{}, fmt.Errorf("ошибка при получении поставщика: %w", translateError(err))
 	}
 
 	return supplier, nil
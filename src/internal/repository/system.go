@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"src/internal/repository/model"
+)
+
+type SystemPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewSystemPostgres(db *pgxpool.Pool) *SystemPostgres {
+	return &SystemPostgres{db: db}
+}
+
+func (r *SystemPostgres) PoolStats() model.PoolStats {
+	stat := r.db.Stat()
+
+	return model.PoolStats{
+		MaxConns:                stat.MaxConns(),
+		TotalConns:              stat.TotalConns(),
+		AcquiredConns:           stat.AcquiredConns(),
+		IdleConns:               stat.IdleConns(),
+		ConstructingConns:       stat.ConstructingConns(),
+		AcquireCount:            stat.AcquireCount(),
+		AcquireDuration:         stat.AcquireDuration(),
+		EmptyAcquireCount:       stat.EmptyAcquireCount(),
+		CanceledAcquireCount:    stat.CanceledAcquireCount(),
+		NewConnsCount:           stat.NewConnsCount(),
+		MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+	}
+}
+
+func (r *SystemPostgres) Ping(ctx context.Context) error {
+	if err := r.db.Ping(ctx); err != nil {
+		return fmt.Errorf("ошибка при проверке соединения с базой данных: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"runtime"
+	"src/internal/metrics"
+	"strings"
+)
+
+type txKey struct{}
+
+type executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// querier возвращает транзакцию из контекста, если она открыта, иначе пул соединений.
+// Запросы через него помечаются именем вызвавшего метода репозитория для метрик.
+func querier(ctx context.Context, db *pgxpool.Pool) executor {
+	var exec executor = db
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		exec = tx
+	}
+	return namedExecutor{executor: exec, name: callerName()}
+}
+
+// namedExecutor передаёт имя метода репозитория в контексте каждого запроса.
+type namedExecutor struct {
+	executor
+	name string
+}
+
+func (e namedExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return e.executor.Exec(metrics.WithQueryName(ctx, e.name), sql, arguments...)
+}
+
+func (e namedExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return e.executor.Query(metrics.WithQueryName(ctx, e.name), sql, args...)
+}
+
+func (e namedExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return e.executor.QueryRow(metrics.WithQueryName(ctx, e.name), sql, args...)
+}
+
+// callerName возвращает имя метода, вызвавшего querier, в виде ProductPostgres.GetProductById.
+func callerName() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name, "repository.")
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
+
+type TransactionPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewTransactionPostgres(db *pgxpool.Pool) *TransactionPostgres {
+	return &TransactionPostgres{db: db}
+}
+
+// WithinTransaction выполняет fn в транзакции. Если транзакция уже открыта в ctx,
+// fn выполняется в ней, а фиксацию выполняет внешний вызов.
+func (r *TransactionPostgres) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка при открытии транзакции: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(context.WithoutCancel(ctx)); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = errors.Join(err, fmt.Errorf("ошибка при откате транзакции: %w", rbErr))
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("ошибка при фиксации транзакции: %w", err)
+	}
+
+	return nil
+}
@@ -2,18 +2,22 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"src/internal/repository/model"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type UserPostgres struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
 }
 
-func NewUserPostgres(db *pgx.Conn) *UserPostgres {
+func NewUserPostgres(db *pgxpool.Pool) *UserPostgres {
 	return &UserPostgres{db: db}
 }
 
@@ -26,39 +30,140 @@ func (r *UserPostgres) AddUser(ctx context.Context, user model.User) (uuid.UUID,
 		RETURNING id;
 	`
 
-	err := r.db.QueryRow(ctx, query, user.ClientName, user.ClientSurname, user.Birthday, user.Gender, user.AddressID).Scan(&id)
+	err := querier(ctx, r.db).QueryRow(ctx, query, user.ClientName, user.ClientSurname, user.Birthday, user.Gender, user.AddressID).Scan(&id)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении пользователя: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении пользователя: %w", translateError(err))
 	}
 
 	return id, nil
 }
 
+func (r *UserPostgres) GetUserByID(ctx context.Context, userID uuid.UUID) (model.User, error) {
+	query := `
+		SELECT id, client_name, client_surname, birthday, gender, registration_date, address_id, version, deleted_at
+		FROM client
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+
+	var user model.User
+	err := querier(ctx, r.db).QueryRow(ctx, query, userID).Scan(&user.ID, &user.ClientName, &user.ClientSurname,
+		&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID, &user.Version, &user.DeletedAt)
+	if err != nil {
+		return model.User{}, fmt.Errorf("ошибка при получении пользователя: %w", translateError(err))
+	}
+
+	return user, nil
+}
+
+// UpdateUser изменяет личные данные клиента, если его версия совпадает с user.Version,
+// и возвращает новое состояние.
+func (r *UserPostgres) UpdateUser(ctx context.Context, user model.User) (model.User, error) {
+	query := `
+		UPDATE client
+		SET client_name = $1, client_surname = $2, birthday = $3, gender = $4, version = version + 1
+		WHERE id = $5 AND version = $6 AND deleted_at IS NULL
+		RETURNING id, client_name, client_surname, birthday, gender, registration_date, address_id, version, deleted_at;
+	`
+
+	var updated model.User
+	err := querier(ctx, r.db).QueryRow(ctx, query, user.ClientName, user.ClientSurname, user.Birthday, user.Gender,
+		user.ID, user.Version).Scan(
+		&updated.ID, &updated.ClientName, &updated.ClientSurname,
+		&updated.Birthday, &updated.Gender, &updated.RegistrationDate, &updated.AddressID, &updated.Version, &updated.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.User{}, versionError(ctx, r.db, "client", user.ID, "user.not_found")
+	}
+	if err != nil {
+		return model.User{}, fmt.Errorf("ошибка при изменении пользователя: %w", translateError(err))
+	}
+
+	return updated, nil
+}
+
 func (r *UserPostgres) GetAddressIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
 	var addressID uuid.UUID
-	query := `SELECT address_id FROM client WHERE id = $1;`
-	err := r.db.QueryRow(ctx, query, userID).Scan(&addressID)
+	query := `SELECT address_id FROM client WHERE id = $1 AND deleted_at IS NULL;`
+	err := querier(ctx, r.db).QueryRow(ctx, query, userID).Scan(&addressID)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при получении address_id пользователя: %w", err)
+		return uuid.Nil, fmt.Errorf("ошибка при получении address_id пользователя: %w", translateError(err))
 	}
 	return addressID, nil
 }
 
-func (r *UserPostgres) DeleteUser(ctx context.Context, userID uuid.UUID) error {
-	query := `DELETE FROM client WHERE id = $1;`
-	_, err := r.db.Exec(ctx, query, userID)
+// DeleteUser помечает клиента удалённым, если его версия совпадает с version.
+// Клиент и его адрес остаются в таблицах до восстановления или окончательной очистки.
+func (r *UserPostgres) DeleteUser(ctx context.Context, userID uuid.UUID, version int) error {
+	query := `
+		UPDATE client
+		SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND version = $2 AND deleted_at IS NULL;
+	`
+	result, err := querier(ctx, r.db).Exec(ctx, query, userID, version)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении пользователя: %w", err)
+		return fmt.Errorf("ошибка при удалении пользователя: %w", translateError(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return versionError(ctx, r.db, "client", userID, "user.not_found")
 	}
 	return nil
 }
 
+// RestoreUser снимает с клиента отметку об удалении и возвращает его новое состояние.
+func (r *UserPostgres) RestoreUser(ctx context.Context, userID uuid.UUID) (model.User, error) {
+	query := `
+		UPDATE client
+		SET deleted_at = NULL, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, client_name, client_surname, birthday, gender, registration_date, address_id, version, deleted_at;
+	`
+
+	var user model.User
+	err := querier(ctx, r.db).QueryRow(ctx, query, userID).Scan(&user.ID, &user.ClientName, &user.ClientSurname,
+		&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID, &user.Version, &user.DeletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.User{}, restoreError(ctx, r.db, "client", userID, "user.not_found", "user.not_deleted")
+	}
+	if err != nil {
+		return model.User{}, fmt.Errorf("ошибка при восстановлении пользователя: %w", translateError(err))
+	}
+
+	return user, nil
+}
+
+// PurgeUsers окончательно удаляет клиентов, удалённых раньше чем retention назад, и возвращает
+// их количество и id их адресов. Клиенты с заказами остаются в таблице для истории заказов.
+func (r *UserPostgres) PurgeUsers(ctx context.Context, retention time.Duration) (int, []uuid.UUID, error) {
+	query := `
+		DELETE FROM client c
+		WHERE c.deleted_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+		  AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.client_id = c.id)
+		RETURNING c.address_id;
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, nil, fmt.Errorf("ошибка при очистке удалённых пользователей: %w", translateError(err))
+	}
+
+	purged, addressIDs, err := scanAddressIDs(rows)
+	if err != nil {
+		return 0, nil, fmt.Errorf("ошибка при очистке удалённых пользователей: %w", err)
+	}
+
+	return purged, addressIDs, nil
+}
+
 func (r *UserPostgres) GetUserNameSurname(ctx context.Context, name, surname string) ([]model.User, error) {
-	query := `SELECT * FROM client WHERE client_name = $1 AND client_surname = $2;`
+	query := `
+		SELECT id, client_name, client_surname, birthday, gender, registration_date, address_id, version, deleted_at
+		FROM client
+		WHERE client_name = $1 AND client_surname = $2 AND deleted_at IS NULL;
+	`
 
-	rows, err := r.db.Query(ctx, query, name, surname)
+	rows, err := querier(ctx, r.db).Query(ctx, query, name, surname)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении пользователей: %w", err)
+		return nil, fmt.Errorf("ошибка при получении пользователей: %w", translateError(err))
 	}
 	defer rows.Close()
 
@@ -67,26 +172,38 @@ func (r *UserPostgres) GetUserNameSurname(ctx context.Context, name, surname str
 		var user model.User
 		if err := rows.Scan(
 			&user.ID, &user.ClientName, &user.ClientSurname,
-			&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID,
+			&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID, &user.Version, &user.DeletedAt,
 		); err != nil {
-			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
 		}
 		users = append(users, user)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка при обработке результатов: %w", err)
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
 	}
 
 	return users, nil
 }
 
-func (r *UserPostgres) GetUserList(ctx context.Context, limit, offset int) ([]model.User, error) {
-	query := `SELECT * FROM client LIMIT $1 OFFSET $2;`
+// userSortKey задаёт порядок списка клиентов: по дате регистрации, затем по id.
+var userSortKey = keyset{column: "registration_date", cast: "timestamp"}
+
+// GetUserList возвращает страницу клиентов; удалённые клиенты попадают в неё только
+// с includeDeleted. Страница содержит на одну строку больше лимита, если за ней есть ещё клиенты.
+func (r *UserPostgres) GetUserList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.User, error) {
+	var conditions []string
+	if !includeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	clause, args := userSortKey.paginate(page, false, conditions, nil)
+	query := `
+		SELECT id, client_name, client_surname, birthday, gender, registration_date, address_id, version, deleted_at
+		FROM client` + clause + `;`
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении пользователей: %w", err)
+		return nil, fmt.Errorf("ошибка при получении пользователей: %w", translateError(err))
 	}
 	defer rows.Close()
 
@@ -95,16 +212,112 @@ func (r *UserPostgres) GetUserList(ctx context.Context, limit, offset int) ([]mo
 		var user model.User
 		if err := rows.Scan(
 			&user.ID, &user.ClientName, &user.ClientSurname,
-			&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID,
+			&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID, &user.Version, &user.DeletedAt,
 		); err != nil {
-			return nil, fmt.Errorf("ошибка при сканировании строки: %w", err)
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
 		}
 		users = append(users, user)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка при обработке результатов: %w", err)
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
 	}
 
 	return users, nil
 }
+
+// userSearchKey задаёт порядок результатов поиска по запросу: по убыванию релевантности, затем по id.
+var userSearchKey = keyset{column: "rank", cast: "float8"}
+
+// SearchUsers ищет клиентов по фильтру. С поисковым запросом результаты упорядочены
+// по релевантности, без него — как в списке клиентов. Страница содержит на одну строку
+// больше лимита, если за ней есть ещё клиенты.
+func (r *UserPostgres) SearchUsers(ctx context.Context, filter model.ClientFilter) ([]model.UserMatch, error) {
+	var (
+		conditions = []string{"c.deleted_at IS NULL"}
+		args       []any
+	)
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	rank := "0"
+	key, desc := userSortKey, false
+	if filter.Query != "" {
+		// Запрос и шаблон по началу строки всегда идут первыми аргументами: $1 и $2.
+		args = append(args, filter.Query, likePrefix(filter.Query))
+		conditions = append(conditions, `(c.client_name ILIKE $2 OR c.client_surname ILIKE $2
+				OR c.client_name || ' ' || c.client_surname ILIKE $2
+				OR c.client_name % $1 OR c.client_surname % $1)`)
+		rank = `CASE WHEN c.client_name ILIKE $2 OR c.client_surname ILIKE $2
+					OR c.client_name || ' ' || c.client_surname ILIKE $2 THEN 1 ELSE 0 END
+				+ GREATEST(similarity(c.client_name, $1), similarity(c.client_surname, $1),
+					similarity(c.client_name || ' ' || c.client_surname, $1))`
+		key, desc = userSearchKey, true
+	}
+
+	if filter.BirthdayFrom != nil {
+		add("c.birthday >= $%d", *filter.BirthdayFrom)
+	}
+	if filter.BirthdayTo != nil {
+		add("c.birthday <= $%d", *filter.BirthdayTo)
+	}
+	if filter.Gender != "" {
+		add("c.gender = $%d", filter.Gender)
+	}
+	if filter.RegisteredFrom != nil {
+		add("c.registration_date >= $%d::date", *filter.RegisteredFrom)
+	}
+	if filter.RegisteredTo != nil {
+		add("c.registration_date < $%d::date + 1", *filter.RegisteredTo)
+	}
+	if filter.City != "" {
+		add("lower(a.city) = lower($%d)", filter.City)
+	}
+	if filter.Country != "" {
+		add("lower(a.country) = lower($%d)", filter.Country)
+	}
+
+	clause, args := key.paginate(filter.Page, desc, nil, args)
+	query := `
+		SELECT id, client_name, client_surname, birthday, gender, registration_date, address_id, version, deleted_at, rank
+		FROM (
+			SELECT c.id, c.client_name, c.client_surname, c.birthday, c.gender, c.registration_date, c.address_id, c.version, c.deleted_at,
+				(` + rank + `)::float8 AS rank
+			FROM client c
+			LEFT JOIN address a ON a.id = c.address_id` + whereClause(conditions) + `
+		) AS found` + clause + `;`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при поиске пользователей: %w", translateError(err))
+	}
+	defer rows.Close()
+
+	var users []model.UserMatch
+	for rows.Next() {
+		var user model.UserMatch
+		if err := rows.Scan(
+			&user.ID, &user.ClientName, &user.ClientSurname,
+			&user.Birthday, &user.Gender, &user.RegistrationDate, &user.AddressID, &user.Version, &user.DeletedAt, &user.Rank,
+		); err != nil {
+			return nil, fmt.Errorf("ошибка при сканировании строки: %w", translateError(err))
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов: %w", translateError(err))
+	}
+
+	return users, nil
+}
+
+// likePrefix превращает строку в шаблон LIKE, совпадающий с её началом.
+// Символы шаблона в самой строке экранируются.
+func likePrefix(value string) string {
+	return likeEscaper.Replace(value) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
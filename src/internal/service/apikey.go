@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"slices"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+	"time"
+)
+
+// Ключи имеют вид sk_<случайная строка>. Первые apiKeyPrefixLength символов
+// сохраняются открыто, чтобы ключ можно было узнать в списке.
+const (
+	apiKeyMarker        = "sk_"
+	apiKeyPrefixLength  = 11
+	maxAPIKeyNameLength = 100
+)
+
+type APIKeyService struct {
+	repoAPIKey   repository.APIKey
+	repoSupplier repository.Supplier
+	tx           repository.Transaction
+}
+
+func NewAPIKeyService(repoAPIKey repository.APIKey, repoSupplier repository.Supplier, tx repository.Transaction) *APIKeyService {
+	return &APIKeyService{
+		repoAPIKey:   repoAPIKey,
+		repoSupplier: repoSupplier,
+		tx:           tx,
+	}
+}
+
+// CreateAPIKey выпускает ключ поставщика. Открытый ключ возвращается только здесь и при ротации.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, supplierID uuid.UUID, name string, scopes []string,
+	expiresAt *time.Time) (model.APIKey, string, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if len(name) > maxAPIKeyNameLength {
+		return model.APIKey{}, "", domain.Invalid("name", "validation.max", maxAPIKeyNameLength)
+	}
+	if len(scopes) == 0 {
+		return model.APIKey{}, "", domain.Invalid("scopes", "api_key.scopes_required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(model.APIKeyScopes, scope) {
+			return model.APIKey{}, "", domain.Invalid("scopes", "api_key.unknown_scope", scope)
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return model.APIKey{}, "", domain.Invalid("expires_at", "api_key.expiry_in_past")
+	}
+
+	if _, err := s.repoSupplier.GetSupplierByID(ctx, supplierID); err != nil {
+		return model.APIKey{}, "", fmt.Errorf("ошибка при получении поставщика: %w", err)
+	}
+
+	key, plainText, err := s.issue(ctx, model.APIKey{
+		SupplierID: supplierID,
+		Name:       name,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+
+	slog.InfoContext(ctx, "api key issued", "supplier_id", supplierID, "api_key_id", key.ID, "scopes", scopes)
+	return key, plainText, nil
+}
+
+// apiKeySortKey задаёт порядок ключей поставщика: от новых к старым, затем по id.
+var apiKeySortKey = sortKey[model.APIKey]{
+	name:  "created_at",
+	value: func(k model.APIKey) string { return formatCursorTime(k.CreatedAt) },
+	id:    func(k model.APIKey) uuid.UUID { return k.ID },
+	valid: timeValue,
+}
+
+// GetSupplierAPIKeys возвращает страницу ключей поставщика, начиная с последних выпущенных,
+// и курсор следующей страницы.
+func (s *APIKeyService) GetSupplierAPIKeys(ctx context.Context, supplierID uuid.UUID, page model.Page) ([]model.APIKey, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if err := apiKeySortKey.check(page, true); err != nil {
+		return nil, nil, err
+	}
+
+	keys, err := s.repoAPIKey.GetSupplierAPIKeys(ctx, supplierID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при получении API-ключей: %w", err)
+	}
+
+	keys, next := apiKeySortKey.next(keys, page, true)
+	return keys, next, nil
+}
+
+// RotateAPIKey отзывает ключ и выпускает вместо него новый с теми же названием и правами.
+// Новый ключ действует до expiresAt, а без него — до того же срока, что и старый. Истёкший
+// ключ не ротируется: вместо него выпускается новый ключ.
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, supplierID, keyID uuid.UUID, expiresAt *time.Time) (model.APIKey, string, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return model.APIKey{}, "", domain.Invalid("expires_at", "api_key.expiry_in_past")
+	}
+
+	var (
+		key       model.APIKey
+		plainText string
+	)
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		old, err := s.repoAPIKey.GetSupplierAPIKeyForUpdate(ctx, supplierID, keyID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении API-ключа: %w", err)
+		}
+
+		if old.ExpiresAt != nil && !old.ExpiresAt.After(time.Now()) {
+			return domain.New(domain.ErrConflict, "api_key.expired")
+		}
+
+		if err := s.repoAPIKey.RevokeAPIKey(ctx, old.ID); err != nil {
+			return err
+		}
+
+		if expiresAt == nil {
+			expiresAt = old.ExpiresAt
+		}
+
+		key, plainText, err = s.issue(ctx, model.APIKey{
+			SupplierID: old.SupplierID,
+			Name:       old.Name,
+			Scopes:     old.Scopes,
+			ExpiresAt:  expiresAt,
+		})
+		return err
+	})
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+
+	slog.InfoContext(ctx, "api key rotated", "supplier_id", supplierID, "revoked_key_id", keyID, "api_key_id", key.ID)
+	return key, plainText, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, supplierID, keyID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		key, err := s.repoAPIKey.GetSupplierAPIKeyForUpdate(ctx, supplierID, keyID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении API-ключа: %w", err)
+		}
+
+		return s.repoAPIKey.RevokeAPIKey(ctx, key.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "api key revoked", "supplier_id", supplierID, "api_key_id", keyID)
+	return nil
+}
+
+// AuthenticateAPIKey проверяет ключ из запроса и отмечает время его использования.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, plainText string) (model.APIKey, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	key, err := s.repoAPIKey.GetAPIKeyByHash(ctx, hashToken(plainText))
+	if errors.Is(err, domain.ErrNotFound) {
+		return model.APIKey{}, domain.New(domain.ErrUnauthorized, "api_key.invalid")
+	}
+	if err != nil {
+		return model.APIKey{}, fmt.Errorf("ошибка при получении API-ключа: %w", err)
+	}
+
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && !time.Now().Before(*key.ExpiresAt)) {
+		slog.WarnContext(ctx, "revoked or expired api key used", "supplier_id", key.SupplierID, "api_key_id", key.ID)
+		return model.APIKey{}, domain.New(domain.ErrUnauthorized, "api_key.invalid")
+	}
+
+	if err := s.repoAPIKey.TouchAPIKey(ctx, key.ID); err != nil {
+		return model.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyService) issue(ctx context.Context, key model.APIKey) (model.APIKey, string, error) {
+	secret, err := randomToken()
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+
+	plainText := apiKeyMarker + secret
+	key.Prefix = plainText[:apiKeyPrefixLength]
+	key.KeyHash = hashToken(plainText)
+
+	key, err = s.repoAPIKey.CreateAPIKey(ctx, key)
+	if err != nil {
+		return model.APIKey{}, "", fmt.Errorf("ошибка при создании API-ключа: %w", err)
+	}
+
+	return key, plainText, nil
+}
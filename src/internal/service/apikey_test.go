@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAPIKeys хранит ключи по id, как таблица supplier_api_keys.
+type fakeAPIKeys struct {
+	repository.APIKey
+	keys map[uuid.UUID]model.APIKey
+}
+
+func (r *fakeAPIKeys) CreateAPIKey(_ context.Context, key model.APIKey) (model.APIKey, error) {
+	key.ID = uuid.New()
+	key.CreatedAt = time.Now()
+	r.keys[key.ID] = key
+	return key, nil
+}
+
+func (r *fakeAPIKeys) GetAPIKeyByHash(_ context.Context, keyHash string) (model.APIKey, error) {
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return model.APIKey{}, domain.ErrNotFound
+}
+
+func (r *fakeAPIKeys) GetSupplierAPIKeyForUpdate(_ context.Context, supplierID, keyID uuid.UUID) (model.APIKey, error) {
+	key, ok := r.keys[keyID]
+	if !ok || key.SupplierID != supplierID {
+		return model.APIKey{}, domain.ErrNotFound
+	}
+	return key, nil
+}
+
+func (r *fakeAPIKeys) RevokeAPIKey(_ context.Context, keyID uuid.UUID) error {
+	key := r.keys[keyID]
+	if key.RevokedAt != nil {
+		return domain.New(domain.ErrConflict, "api_key.revoked")
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	r.keys[keyID] = key
+	return nil
+}
+
+func (r *fakeAPIKeys) TouchAPIKey(_ context.Context, keyID uuid.UUID) error {
+	key := r.keys[keyID]
+	now := time.Now()
+	key.LastUsedAt = &now
+	r.keys[keyID] = key
+	return nil
+}
+
+type fakeSuppliers struct {
+	repository.Supplier
+	ids []uuid.UUID
+}
+
+func (r fakeSuppliers) GetSupplierByID(_ context.Context, supplierID uuid.UUID) (model.Supplier, error) {
+	for _, id := range r.ids {
+		if id == supplierID {
+			return model.Supplier{ID: id}, nil
+		}
+	}
+	return model.Supplier{}, domain.ErrNotFound
+}
+
+func newTestAPIKeyService(supplierIDs ...uuid.UUID) (*APIKeyService, *fakeAPIKeys) {
+	keys := &fakeAPIKeys{keys: make(map[uuid.UUID]model.APIKey)}
+	return NewAPIKeyService(keys, fakeSuppliers{ids: supplierIDs}, fakeTx{db: newFakeDB()}), keys
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	supplierID := uuid.New()
+	service, keys := newTestAPIKeyService(supplierID)
+	scopes := []string{model.ScopeProductsRead, model.ScopeStockWrite}
+
+	key, plainText, err := service.CreateAPIKey(context.Background(), supplierID, "Склад", scopes, nil)
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+
+	if !strings.HasPrefix(plainText, apiKeyMarker) || len(plainText) <= apiKeyPrefixLength {
+		t.Fatalf("plainText = %q, want %s<random>", plainText, apiKeyMarker)
+	}
+	if key.Prefix != plainText[:apiKeyPrefixLength] {
+		t.Fatalf("prefix = %q, want %q", key.Prefix, plainText[:apiKeyPrefixLength])
+	}
+
+	stored := keys.keys[key.ID]
+	if stored.KeyHash != hashToken(plainText) {
+		t.Fatal("в базе хранится не SHA-256 от открытого ключа")
+	}
+	if strings.Contains(stored.KeyHash, plainText[len(apiKeyMarker):]) {
+		t.Fatal("открытый ключ попал в базу")
+	}
+
+	_, other, err := service.CreateAPIKey(context.Background(), supplierID, "Склад", scopes, nil)
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if other == plainText || hashToken(other) == stored.KeyHash {
+		t.Fatal("два ключа совпали")
+	}
+}
+
+func TestCreateAPIKeyValidation(t *testing.T) {
+	supplierID := uuid.New()
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		supplierID uuid.UUID
+		keyName    string
+		scopes     []string
+		expiresAt  *time.Time
+		wantKind   error
+		wantKey    string
+	}{
+		{name: "long name", supplierID: supplierID, keyName: strings.Repeat("к", maxAPIKeyNameLength+1), scopes: []string{model.ScopeProductsRead}, wantKind: domain.ErrValidation, wantKey: "validation.max"},
+		{name: "no scopes", supplierID: supplierID, keyName: "Склад", wantKind: domain.ErrValidation, wantKey: "api_key.scopes_required"},
+		{name: "unknown scope", supplierID: supplierID, keyName: "Склад", scopes: []string{"orders:write"}, wantKind: domain.ErrValidation, wantKey: "api_key.unknown_scope"},
+		{name: "expiry in the past", supplierID: supplierID, keyName: "Склад", scopes: []string{model.ScopeProductsRead}, expiresAt: &past, wantKind: domain.ErrValidation, wantKey: "api_key.expiry_in_past"},
+		{name: "unknown supplier", supplierID: uuid.New(), keyName: "Склад", scopes: []string{model.ScopeProductsRead}, wantKind: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, keys := newTestAPIKeyService(supplierID)
+
+			_, _, err := service.CreateAPIKey(context.Background(), tt.supplierID, tt.keyName, tt.scopes, tt.expiresAt)
+
+			if tt.wantKey != "" {
+				checkDomainError(t, err, tt.wantKind, tt.wantKey)
+			} else if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("err = %v, want %v", err, tt.wantKind)
+			}
+			if len(keys.keys) != 0 {
+				t.Fatal("ключ выпущен несмотря на ошибку")
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	supplierID := uuid.New()
+	service, keys := newTestAPIKeyService(supplierID)
+
+	key, plainText, err := service.CreateAPIKey(context.Background(), supplierID, "Склад", []string{model.ScopeStockWrite}, nil)
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+
+	got, err := service.AuthenticateAPIKey(context.Background(), plainText)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIKey: %v", err)
+	}
+	if got.ID != key.ID || got.SupplierID != supplierID {
+		t.Fatalf("key = %+v, want %s of supplier %s", got, key.ID, supplierID)
+	}
+	if keys.keys[key.ID].LastUsedAt == nil {
+		t.Fatal("время использования ключа не обновлено")
+	}
+}
+
+func TestAuthenticateAPIKeyRejected(t *testing.T) {
+	supplierID := uuid.New()
+	past := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name  string
+		edit  func(key *model.APIKey)
+		token func(plainText string) string
+	}{
+		{name: "unknown key", token: func(string) string { return apiKeyMarker + "unknown" }},
+		{name: "same prefix", token: func(plainText string) string { return plainText[:apiKeyPrefixLength] + "tampered" }},
+		{name: "changed last character", token: func(plainText string) string {
+			last := plainText[len(plainText)-1]
+			replacement := "A"
+			if last == 'A' {
+				replacement = "B"
+			}
+			return plainText[:len(plainText)-1] + replacement
+		}},
+		{name: "hash instead of key", token: func(plainText string) string { return hashToken(plainText) }},
+		{name: "revoked", edit: func(key *model.APIKey) { key.RevokedAt = &past }},
+		{name: "expired", edit: func(key *model.APIKey) { key.ExpiresAt = &past }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, keys := newTestAPIKeyService(supplierID)
+			key, plainText, err := service.CreateAPIKey(context.Background(), supplierID, "Склад", []string{model.ScopeStockWrite}, nil)
+			if err != nil {
+				t.Fatalf("CreateAPIKey: %v", err)
+			}
+			if tt.edit != nil {
+				stored := keys.keys[key.ID]
+				tt.edit(&stored)
+				keys.keys[key.ID] = stored
+			}
+			if tt.token != nil {
+				plainText = tt.token(plainText)
+			}
+
+			_, err = service.AuthenticateAPIKey(context.Background(), plainText)
+
+			checkDomainError(t, err, domain.ErrUnauthorized, "api_key.invalid")
+			if keys.keys[key.ID].LastUsedAt != nil {
+				t.Fatal("отклонённый ключ отмечен как использованный")
+			}
+		})
+	}
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	supplierID := uuid.New()
+	inMonth := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	inYear := time.Now().Add(365 * 24 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		oldExpiry  *time.Time
+		newExpiry  *time.Time
+		wantExpiry *time.Time
+	}{
+		{name: "key without expiry"},
+		{name: "keeps the old expiry", oldExpiry: &inMonth, wantExpiry: &inMonth},
+		{name: "new expiry from the request", oldExpiry: &inMonth, newExpiry: &inYear, wantExpiry: &inYear},
+		{name: "adds expiry to a key without one", newExpiry: &inYear, wantExpiry: &inYear},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, keys := newTestAPIKeyService(supplierID)
+			scopes := []string{model.ScopeProductsRead, model.ScopePricesWrite}
+			old, oldPlainText, err := service.CreateAPIKey(context.Background(), supplierID, "Склад", scopes, tt.oldExpiry)
+			if err != nil {
+				t.Fatalf("CreateAPIKey: %v", err)
+			}
+
+			key, plainText, err := service.RotateAPIKey(context.Background(), supplierID, old.ID, tt.newExpiry)
+			if err != nil {
+				t.Fatalf("RotateAPIKey: %v", err)
+			}
+
+			if key.ID == old.ID || plainText == oldPlainText || key.KeyHash != hashToken(plainText) {
+				t.Fatal("ротация не выпустила новый ключ")
+			}
+			if key.Name != old.Name || strings.Join(key.Scopes, ",") != strings.Join(scopes, ",") {
+				t.Fatalf("key = %+v, want name and scopes of the old key", key)
+			}
+			switch {
+			case tt.wantExpiry == nil && key.ExpiresAt != nil:
+				t.Fatalf("expires_at = %v, want none", *key.ExpiresAt)
+			case tt.wantExpiry != nil && (key.ExpiresAt == nil || !key.ExpiresAt.Equal(*tt.wantExpiry)):
+				t.Fatalf("expires_at = %v, want %v", key.ExpiresAt, *tt.wantExpiry)
+			}
+			if keys.keys[old.ID].RevokedAt == nil {
+				t.Fatal("старый ключ не отозван")
+			}
+
+			if _, err := service.AuthenticateAPIKey(context.Background(), oldPlainText); !errors.Is(err, domain.ErrUnauthorized) {
+				t.Fatalf("old key: err = %v, want %v", err, domain.ErrUnauthorized)
+			}
+			if _, err := service.AuthenticateAPIKey(context.Background(), plainText); err != nil {
+				t.Fatalf("new key: %v", err)
+			}
+		})
+	}
+}
+
+func TestRotateAPIKeyRejected(t *testing.T) {
+	supplierID := uuid.New()
+	past := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name       string
+		edit       func(key *model.APIKey)
+		supplierID uuid.UUID
+		newExpiry  *time.Time
+		wantKind   error
+		wantKey    string
+	}{
+		{name: "expired key", edit: func(key *model.APIKey) { key.ExpiresAt = &past }, wantKind: domain.ErrConflict, wantKey: "api_key.expired"},
+		{name: "revoked key", edit: func(key *model.APIKey) { key.RevokedAt = &past }, wantKind: domain.ErrConflict, wantKey: "api_key.revoked"},
+		{name: "new expiry in the past", newExpiry: &past, wantKind: domain.ErrValidation, wantKey: "api_key.expiry_in_past"},
+		{name: "key of another supplier", supplierID: uuid.New(), wantKind: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, keys := newTestAPIKeyService(supplierID)
+			old, _, err := service.CreateAPIKey(context.Background(), supplierID, "Склад", []string{model.ScopeStockWrite}, nil)
+			if err != nil {
+				t.Fatalf("CreateAPIKey: %v", err)
+			}
+			if tt.edit != nil {
+				stored := keys.keys[old.ID]
+				tt.edit(&stored)
+				keys.keys[old.ID] = stored
+			}
+			before := keys.keys[old.ID]
+
+			owner := supplierID
+			if tt.supplierID != uuid.Nil {
+				owner = tt.supplierID
+			}
+			_, _, err = service.RotateAPIKey(context.Background(), owner, old.ID, tt.newExpiry)
+
+			if tt.wantKey != "" {
+				checkDomainError(t, err, tt.wantKind, tt.wantKey)
+			} else if !errors.Is(err, tt.wantKind) {
+				t.Fatalf("err = %v, want %v", err, tt.wantKind)
+			}
+			if len(keys.keys) != 1 {
+				t.Fatal("новый ключ выпущен несмотря на ошибку")
+			}
+			if after := keys.keys[old.ID]; after.RevokedAt != before.RevokedAt {
+				t.Fatal("старый ключ изменён несмотря на ошибку")
+			}
+		})
+	}
+}
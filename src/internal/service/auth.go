@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"slices"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+	"time"
+)
+
+// minPasswordLength — минимальная длина пароля сотрудника или клиента.
+const minPasswordLength = 8
+
+type AuthService struct {
+	repoStaff  repository.Staff
+	tx         repository.Transaction
+	tokens     *accessTokens
+	refreshTTL time.Duration
+}
+
+func NewAuthService(repoStaff repository.Staff, tx repository.Transaction, tokens *accessTokens, refreshTTL time.Duration) *AuthService {
+	return &AuthService{
+		repoStaff:  repoStaff,
+		tx:         tx,
+		tokens:     tokens,
+		refreshTTL: refreshTTL,
+	}
+}
+
+func (s *AuthService) CreateStaffUser(ctx context.Context, login, password, role string) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if !slices.Contains(model.StaffRoles, role) {
+		return uuid.Nil, domain.Invalid("role", "auth.unknown_role", role)
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := s.repoStaff.CreateStaffUser(ctx, model.StaffUser{
+		Login:        login,
+		PasswordHash: hash,
+		Role:         role,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("ошибка при добавлении сотрудника: %w", err)
+	}
+
+	slog.InfoContext(ctx, "staff user created", "staff_user_id", id, "role", role)
+	return id, nil
+}
+
+// Login проверяет логин и пароль сотрудника и выдаёт новую пару токенов.
+// Неизвестный логин и неверный пароль не различаются для клиента ни ответом, ни временем ответа.
+func (s *AuthService) Login(ctx context.Context, login, password string) (model.TokenPair, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	user, err := s.repoStaff.GetStaffUserByLogin(ctx, login)
+	if errors.Is(err, domain.ErrNotFound) {
+		compareDummyPassword(password)
+		slog.WarnContext(ctx, "staff login failed: unknown login", "login", login)
+		return model.TokenPair{}, domain.New(domain.ErrUnauthorized, "auth.invalid_credentials")
+	}
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("ошибка при получении сотрудника: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		slog.WarnContext(ctx, "staff login failed: wrong password", "staff_user_id", user.ID)
+		return model.TokenPair{}, domain.New(domain.ErrUnauthorized, "auth.invalid_credentials")
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// Refresh обменивает токен обновления на новую пару токенов. Использованный токен отзывается.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var tokens model.TokenPair
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.repoStaff.GetRefreshTokenForUpdate(ctx, hashToken(refreshToken))
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.New(domain.ErrUnauthorized, "auth.invalid_refresh_token")
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка при получении токена обновления: %w", err)
+		}
+
+		if token.RevokedAt != nil || !time.Now().Before(token.ExpiresAt) {
+			return domain.New(domain.ErrUnauthorized, "auth.invalid_refresh_token")
+		}
+
+		if err := s.repoStaff.RevokeRefreshToken(ctx, token.ID); err != nil {
+			return err
+		}
+
+		user, err := s.repoStaff.GetStaffUserByID(ctx, token.StaffUserID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении сотрудника: %w", err)
+		}
+
+		tokens, err = s.issueTokens(ctx, user)
+		return err
+	})
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return tokens, nil
+}
+
+// ParseAccessToken проверяет токен доступа сотрудника или клиента.
+func (s *AuthService) ParseAccessToken(accessToken string) (model.Principal, error) {
+	return s.tokens.parse(accessToken)
+}
+
+func (s *AuthService) issueTokens(ctx context.Context, user model.StaffUser) (model.TokenPair, error) {
+	accessToken, expiresAt, err := s.tokens.sign(model.Principal{ID: user.ID, Login: user.Login, Role: user.Role})
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	err = s.repoStaff.CreateRefreshToken(ctx, model.RefreshToken{
+		StaffUserID: user.ID,
+		TokenHash:   hashToken(refreshToken),
+		ExpiresAt:   time.Now().Add(s.refreshTTL),
+	})
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("ошибка при сохранении токена обновления: %w", err)
+	}
+
+	return model.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
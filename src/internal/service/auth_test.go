@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testJWTSecret = "test-secret-that-is-long-enough-for-hs256"
+	testIssuer    = "shop-test"
+)
+
+// fakeStaff хранит сотрудников и токены обновления по хешу.
+type fakeStaff struct {
+	repository.Staff
+	users  map[uuid.UUID]model.StaffUser
+	tokens map[string]model.RefreshToken
+}
+
+func newFakeStaff(users ...model.StaffUser) *fakeStaff {
+	staff := &fakeStaff{users: make(map[uuid.UUID]model.StaffUser), tokens: make(map[string]model.RefreshToken)}
+	for _, user := range users {
+		staff.users[user.ID] = user
+	}
+	return staff
+}
+
+func (r *fakeStaff) GetStaffUserByLogin(_ context.Context, login string) (model.StaffUser, error) {
+	for _, user := range r.users {
+		if user.Login == login {
+			return user, nil
+		}
+	}
+	return model.StaffUser{}, domain.ErrNotFound
+}
+
+func (r *fakeStaff) GetStaffUserByID(_ context.Context, userID uuid.UUID) (model.StaffUser, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return model.StaffUser{}, domain.ErrNotFound
+	}
+	return user, nil
+}
+
+func (r *fakeStaff) CreateRefreshToken(_ context.Context, token model.RefreshToken) error {
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r *fakeStaff) GetRefreshTokenForUpdate(_ context.Context, tokenHash string) (model.RefreshToken, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return model.RefreshToken{}, domain.ErrNotFound
+	}
+	return token, nil
+}
+
+func (r *fakeStaff) RevokeRefreshToken(_ context.Context, tokenID uuid.UUID) error {
+	for hash, token := range r.tokens {
+		if token.ID == tokenID {
+			now := time.Now()
+			token.RevokedAt = &now
+			r.tokens[hash] = token
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// newTestStaffUser возвращает сотрудника с паролем password.
+func newTestStaffUser(t *testing.T, login, password, role string) model.StaffUser {
+	t.Helper()
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+	return model.StaffUser{ID: uuid.New(), Login: login, PasswordHash: hash, Role: role}
+}
+
+func newTestAuthService(staff *fakeStaff) *AuthService {
+	return NewAuthService(staff, fakeTx{db: newFakeDB()}, newAccessTokens([]byte(testJWTSecret), testIssuer, time.Minute), time.Hour)
+}
+
+// signTestToken подписывает произвольные claims, чтобы собрать токен, который сервис не выдал бы сам.
+func signTestToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return token
+}
+
+func TestAccessTokensRoundTrip(t *testing.T) {
+	tokens := newAccessTokens([]byte(testJWTSecret), testIssuer, time.Minute)
+	principal := model.Principal{ID: uuid.New(), Login: "manager", Role: model.RoleCatalogManager}
+
+	token, expiresAt, err := tokens.sign(principal)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if until := time.Until(expiresAt); until <= 0 || until > time.Minute {
+		t.Fatalf("expiresAt = %v, want within the TTL", expiresAt)
+	}
+
+	got, err := tokens.parse(token)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got != principal {
+		t.Fatalf("principal = %+v, want %+v", got, principal)
+	}
+}
+
+func TestAccessTokensRejected(t *testing.T) {
+	tokens := newAccessTokens([]byte(testJWTSecret), testIssuer, time.Minute)
+	now := time.Now()
+
+	claims := func(edit func(*accessClaims)) accessClaims {
+		c := accessClaims{
+			Login: "manager",
+			Role:  model.RoleAdmin,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    testIssuer,
+				Subject:   uuid.NewString(),
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			},
+		}
+		edit(&c)
+		return c
+	}
+	valid := signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims(func(*accessClaims) {}))
+	parts := strings.Split(valid, ".")
+
+	expired, _, err := newAccessTokens([]byte(testJWTSecret), testIssuer, -time.Minute).
+		sign(model.Principal{ID: uuid.New(), Login: "manager", Role: model.RoleAdmin})
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "wrong signature", token: signTestToken(t, jwt.SigningMethodHS256, []byte("another-secret-that-is-long-enough-for-hs256"), claims(func(*accessClaims) {}))},
+		{name: "tampered payload", token: parts[0] + "." + encodeTestClaims(t, claims(func(c *accessClaims) { c.Role = model.RoleAdmin; c.Login = "root" })) + "." + parts[2]},
+		{name: "truncated signature", token: parts[0] + "." + parts[1] + "." + parts[2][:len(parts[2])/2]},
+		{name: "expired", token: expired},
+		{name: "expired with valid signature", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims(func(c *accessClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Second))
+		}))},
+		{name: "without expiry", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims(func(c *accessClaims) {
+			c.ExpiresAt = nil
+		}))},
+		{name: "another issuer", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims(func(c *accessClaims) {
+			c.Issuer = "someone-else"
+		}))},
+		{name: "another algorithm", token: signTestToken(t, jwt.SigningMethodHS512, []byte(testJWTSecret), claims(func(*accessClaims) {}))},
+		{name: "unsigned", token: signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(func(*accessClaims) {}))},
+		{name: "unknown role", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims(func(c *accessClaims) {
+			c.Role = "superuser"
+		}))},
+		{name: "subject is not a uuid", token: signTestToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims(func(c *accessClaims) {
+			c.Subject = "manager"
+		}))},
+		{name: "not a token", token: "not-a-token"},
+		{name: "empty", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokens.parse(tt.token)
+
+			checkDomainError(t, err, domain.ErrUnauthorized, "auth.invalid_token")
+		})
+	}
+}
+
+// encodeTestClaims возвращает закодированную среднюю часть JWT с claims.
+func encodeTestClaims(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+
+	token := signTestToken(t, jwt.SigningMethodHS256, []byte("unused"), claims)
+	return strings.Split(token, ".")[1]
+}
+
+func TestLogin(t *testing.T) {
+	user := newTestStaffUser(t, "manager", "correct-password", model.RoleWarehouse)
+
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		wantErr  bool
+	}{
+		{name: "valid credentials", login: "manager", password: "correct-password"},
+		{name: "wrong password", login: "manager", password: "wrong-password", wantErr: true},
+		{name: "unknown login", login: "stranger", password: "correct-password", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staff := newFakeStaff(user)
+			service := newTestAuthService(staff)
+
+			tokens, err := service.Login(context.Background(), tt.login, tt.password)
+
+			if tt.wantErr {
+				// Неизвестный логин и неверный пароль неразличимы для клиента.
+				checkDomainError(t, err, domain.ErrUnauthorized, "auth.invalid_credentials")
+				if len(staff.tokens) != 0 {
+					t.Fatal("токен обновления выдан при неудачном входе")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Login: %v", err)
+			}
+
+			principal, err := service.ParseAccessToken(tokens.AccessToken)
+			if err != nil {
+				t.Fatalf("ParseAccessToken: %v", err)
+			}
+			if principal.ID != user.ID || principal.Role != user.Role {
+				t.Fatalf("principal = %+v, want user %s with role %s", principal, user.ID, user.Role)
+			}
+			if _, ok := staff.tokens[hashToken(tokens.RefreshToken)]; !ok {
+				t.Fatal("в базе нет хеша выданного токена обновления")
+			}
+			if _, ok := staff.tokens[tokens.RefreshToken]; ok {
+				t.Fatal("токен обновления сохранён в открытом виде")
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	user := newTestStaffUser(t, "manager", "correct-password", model.RoleAdmin)
+	staff := newFakeStaff(user)
+	service := newTestAuthService(staff)
+
+	first, err := service.Login(context.Background(), "manager", "correct-password")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	second, err := service.Refresh(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("токен обновления не сменился")
+	}
+	if principal, err := service.ParseAccessToken(second.AccessToken); err != nil || principal.ID != user.ID {
+		t.Fatalf("ParseAccessToken = %+v, %v", principal, err)
+	}
+
+	// Использованный токен отозван и второй раз не принимается.
+	_, err = service.Refresh(context.Background(), first.RefreshToken)
+	checkDomainError(t, err, domain.ErrUnauthorized, "auth.invalid_refresh_token")
+
+	if _, err := service.Refresh(context.Background(), second.RefreshToken); err != nil {
+		t.Fatalf("Refresh with the new token: %v", err)
+	}
+}
+
+func TestRefreshRejected(t *testing.T) {
+	user := newTestStaffUser(t, "manager", "correct-password", model.RoleAdmin)
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		token *model.RefreshToken
+	}{
+		{name: "unknown token"},
+		{name: "expired", token: &model.RefreshToken{ExpiresAt: time.Now().Add(-time.Second)}},
+		{name: "revoked", token: &model.RefreshToken{ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staff := newFakeStaff(user)
+			refreshToken := "opaque-refresh-token"
+			if tt.token != nil {
+				token := *tt.token
+				token.ID = uuid.New()
+				token.StaffUserID = user.ID
+				token.TokenHash = hashToken(refreshToken)
+				staff.tokens[token.TokenHash] = token
+			}
+
+			_, err := newTestAuthService(staff).Refresh(context.Background(), refreshToken)
+
+			checkDomainError(t, err, domain.ErrUnauthorized, "auth.invalid_refresh_token")
+			if len(staff.tokens) > 1 {
+				t.Fatal("новый токен обновления выдан по недействительному токену")
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"src/internal/domain"
+	"src/internal/i18n"
+	"src/internal/logger"
+	"src/internal/mail"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+	"strings"
+	"time"
+)
+
+// ClientLinks задаёт ссылки в письмах клиенту. Вместо {token} подставляется одноразовый токен.
+type ClientLinks struct {
+	VerifyEmailURL   string
+	ResetPasswordURL string
+}
+
+type ClientService struct {
+	repoAccount repository.ClientAccount
+	users       User
+	tx          repository.Transaction
+	tokens      *accessTokens
+	mail        mail.Sender
+	links       ClientLinks
+	verifyTTL   time.Duration
+	resetTTL    time.Duration
+}
+
+func NewClientService(repoAccount repository.ClientAccount, users User, tx repository.Transaction, tokens *accessTokens,
+	sender mail.Sender, links ClientLinks, verifyTTL, resetTTL time.Duration) *ClientService {
+	return &ClientService{
+		repoAccount: repoAccount,
+		users:       users,
+		tx:          tx,
+		tokens:      tokens,
+		mail:        sender,
+		links:       links,
+		verifyTTL:   verifyTTL,
+		resetTTL:    resetTTL,
+	}
+}
+
+// RegisterClient создаёт клиента с учётными данными и отправляет письмо для подтверждения адреса почты.
+// Если письмо отправить не удалось, регистрация отменяется.
+func (s *ClientService) RegisterClient(ctx context.Context, user model.User, address model.Address, email, password string) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	email = normalizeEmail(email)
+
+	var id uuid.UUID
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		id, err = s.users.AddUser(ctx, user, address)
+		if err != nil {
+			return err
+		}
+
+		err = s.repoAccount.CreateClientCredentials(ctx, model.ClientCredentials{
+			ClientID:     id,
+			Email:        email,
+			PasswordHash: hash,
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении учётных данных клиента: %w", err)
+		}
+
+		return s.sendToken(ctx, id, email, model.ClientTokenVerifyEmail)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	slog.InfoContext(ctx, "client registered", "client_id", id)
+	return id, nil
+}
+
+// LoginClient проверяет адрес почты и пароль клиента и выдаёт токен доступа.
+// Войти можно только после подтверждения адреса почты.
+func (s *ClientService) LoginClient(ctx context.Context, email, password string) (model.TokenPair, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	credentials, err := s.repoAccount.GetClientCredentialsByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, domain.ErrNotFound) {
+		compareDummyPassword(password)
+		return model.TokenPair{}, domain.New(domain.ErrUnauthorized, "client.invalid_credentials")
+	}
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("ошибка при получении учётных данных клиента: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(credentials.PasswordHash), []byte(password)); err != nil {
+		return model.TokenPair{}, domain.New(domain.ErrUnauthorized, "client.invalid_credentials")
+	}
+
+	if credentials.EmailVerifiedAt == nil {
+		return model.TokenPair{}, domain.New(domain.ErrForbidden, "client.email_not_verified")
+	}
+
+	accessToken, expiresAt, err := s.tokens.sign(model.Principal{
+		ID:    credentials.ClientID,
+		Login: credentials.Email,
+		Role:  model.RoleClient,
+	})
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return model.TokenPair{AccessToken: accessToken, ExpiresAt: expiresAt}, nil
+}
+
+func (s *ClientService) VerifyClientEmail(ctx context.Context, token string) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		clientID, err := s.useToken(ctx, model.ClientTokenVerifyEmail, token)
+		if err != nil {
+			return err
+		}
+
+		return s.repoAccount.MarkClientEmailVerified(ctx, clientID)
+	})
+}
+
+// RequestClientPasswordReset отправляет письмо со ссылкой для смены пароля. Для неизвестного
+// адреса почты ошибка не возвращается, чтобы по ответу нельзя было узнать, зарегистрирован ли он.
+// По той же причине токен и письмо для известного адреса создаются в фоне: иначе ответ
+// на такой адрес приходил бы заметно позже.
+func (s *ClientService) RequestClientPasswordReset(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	credentials, err := s.repoAccount.GetClientCredentialsByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка при получении учётных данных клиента: %w", err)
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		err := s.sendToken(ctx, credentials.ClientID, credentials.Email, model.ClientTokenResetPassword)
+		if err != nil {
+			slog.ErrorContext(ctx, "password reset mail failed", "client_id", credentials.ClientID, logger.Err(err))
+		}
+	}()
+
+	return nil
+}
+
+func (s *ClientService) ResetClientPassword(ctx context.Context, token, password string) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		clientID, err := s.useToken(ctx, model.ClientTokenResetPassword, token)
+		if err != nil {
+			return err
+		}
+
+		return s.repoAccount.SetClientPassword(ctx, clientID, hash)
+	})
+}
+
+// sendToken сохраняет новый одноразовый токен и отправляет клиенту письмо со ссылкой на него.
+func (s *ClientService) sendToken(ctx context.Context, clientID uuid.UUID, email, purpose string) error {
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+
+	ttl, link, messageKey := s.verifyTTL, s.links.VerifyEmailURL, "mail.verify_email"
+	if purpose == model.ClientTokenResetPassword {
+		ttl, link, messageKey = s.resetTTL, s.links.ResetPasswordURL, "mail.reset_password"
+	}
+
+	err = s.repoAccount.CreateClientToken(ctx, model.ClientToken{
+		ClientID:  clientID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении токена клиента: %w", err)
+	}
+
+	err = s.mail.Send(ctx, mail.Message{
+		To:      email,
+		Subject: i18n.Translate(i18n.Fallback, messageKey+"_subject"),
+		Body:    i18n.Translate(i18n.Fallback, messageKey+"_body", strings.ReplaceAll(link, "{token}", token), ttl),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка при отправке письма: %w", err)
+	}
+
+	return nil
+}
+
+// useToken помечает одноразовый токен использованным и возвращает клиента, которому он выдан.
+func (s *ClientService) useToken(ctx context.Context, purpose, token string) (uuid.UUID, error) {
+	clientToken, err := s.repoAccount.GetClientTokenForUpdate(ctx, purpose, hashToken(token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return uuid.Nil, domain.Invalid("token", "client.invalid_token")
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("ошибка при получении токена клиента: %w", err)
+	}
+
+	if clientToken.UsedAt != nil || !time.Now().Before(clientToken.ExpiresAt) {
+		return uuid.Nil, domain.Invalid("token", "client.invalid_token")
+	}
+
+	if err := s.repoAccount.MarkClientTokenUsed(ctx, clientToken.ID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return clientToken.ClientID, nil
+}
+
+// dummyPasswordHash — bcrypt-хеш произвольного пароля со стоимостью bcrypt.DefaultCost,
+// как у настоящих паролей.
+const dummyPasswordHash = "$2a$10$3D2lVI7VMU3hnGT4R4N9pe25DHTSxT5kRAW2g0LdAoJPSCRD.2gba"
+
+// compareDummyPassword тратит на неизвестный логин столько же времени, сколько проверка
+// пароля существующей учётной записи, чтобы по времени ответа нельзя было перебрать логины.
+func compareDummyPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", domain.Invalid("password", "auth.password_too_short", minPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("ошибка при хешировании пароля: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"testing"
+	"time"
+)
+
+// fakeState — данные поддельной базы. Транзакция запоминает копию состояния
+// и восстанавливает её, если функция вернула ошибку.
+type fakeState struct {
+	products     map[uuid.UUID]model.Product
+	movements    []model.StockMovement
+	orders       []model.Order
+	orderItems   []model.OrderItem
+	reservations map[uuid.UUID]model.Reservation
+}
+
+func (s fakeState) clone() fakeState {
+	return fakeState{
+		products:     maps.Clone(s.products),
+		movements:    slices.Clone(s.movements),
+		orders:       slices.Clone(s.orders),
+		orderItems:   slices.Clone(s.orderItems),
+		reservations: maps.Clone(s.reservations),
+	}
+}
+
+// fakeDB хранит состояние и считает зафиксированные и откаченные транзакции.
+type fakeDB struct {
+	fakeState
+	commits   int
+	rollbacks int
+}
+
+func newFakeDB(products ...model.Product) *fakeDB {
+	db := &fakeDB{fakeState: fakeState{
+		products:     make(map[uuid.UUID]model.Product),
+		reservations: make(map[uuid.UUID]model.Reservation),
+	}}
+	for _, product := range products {
+		db.products[product.ID] = product
+	}
+	return db
+}
+
+type fakeTxKey struct{}
+
+// fakeTx повторяет поведение TransactionPostgres: вложенный вызов присоединяется
+// к внешней транзакции, а фиксирует или откатывает её только внешний.
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (t fakeTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(fakeTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	snapshot := t.db.fakeState.clone()
+	if err := fn(context.WithValue(ctx, fakeTxKey{}, true)); err != nil {
+		t.db.fakeState = snapshot
+		t.db.rollbacks++
+		return err
+	}
+
+	t.db.commits++
+	return nil
+}
+
+// fakeProducts реализует методы репозитория товаров, которые нужны сервисам склада.
+// Вызов остальных методов паникует через nil-интерфейс.
+type fakeProducts struct {
+	repository.Product
+	db *fakeDB
+}
+
+func (r fakeProducts) ChangeStock(_ context.Context, productID uuid.UUID, delta, version int) (int, error) {
+	product, ok := r.db.products[productID]
+	if !ok || product.DeletedAt != nil {
+		return 0, domain.New(domain.ErrNotFound, "product.not_found")
+	}
+	if version > 0 && product.Version != version {
+		return 0, domain.New(domain.ErrVersionMismatch, "error.version_mismatch")
+	}
+	if product.AvailableStock+delta < product.ReservedStock {
+		return 0, domain.ErrInsufficientStock
+	}
+
+	product.AvailableStock += delta
+	product.Version++
+	r.db.products[productID] = product
+	return product.AvailableStock, nil
+}
+
+func (r fakeProducts) ReturnStock(_ context.Context, productID uuid.UUID, quantity int) (int, error) {
+	product, ok := r.db.products[productID]
+	if !ok {
+		return 0, domain.New(domain.ErrNotFound, "product.not_found")
+	}
+
+	product.AvailableStock += quantity
+	product.Version++
+	r.db.products[productID] = product
+	return product.AvailableStock, nil
+}
+
+func (r fakeProducts) ReserveStock(_ context.Context, productID uuid.UUID, quantity int) error {
+	product, ok := r.db.products[productID]
+	if !ok || product.DeletedAt != nil {
+		return domain.New(domain.ErrNotFound, "product.not_found")
+	}
+	if product.AvailableStock-product.ReservedStock < quantity {
+		return domain.ErrInsufficientStock
+	}
+
+	product.ReservedStock += quantity
+	product.Version++
+	r.db.products[productID] = product
+	return nil
+}
+
+func (r fakeProducts) ReleaseStock(_ context.Context, productID uuid.UUID, quantity int) error {
+	product, ok := r.db.products[productID]
+	if !ok || product.ReservedStock < quantity {
+		return domain.New(domain.ErrConflict, "stock.release_conflict")
+	}
+
+	product.ReservedStock -= quantity
+	product.Version++
+	r.db.products[productID] = product
+	return nil
+}
+
+func (r fakeProducts) GetProductById(_ context.Context, productID uuid.UUID) (model.Product, error) {
+	product, ok := r.db.products[productID]
+	if !ok || product.DeletedAt != nil {
+		return model.Product{}, domain.ErrNotFound
+	}
+	return product, nil
+}
+
+type fakeStock struct {
+	repository.Stock
+	db *fakeDB
+}
+
+func (r fakeStock) AddStockMovement(_ context.Context, movement model.StockMovement) (model.StockMovement, error) {
+	product, ok := r.db.products[movement.ProductID]
+	if !ok {
+		return model.StockMovement{}, domain.ErrNotFound
+	}
+
+	movement.ID = uuid.New()
+	movement.ProductName = product.Name
+	movement.CreatedAt = time.Now()
+	r.db.movements = append(r.db.movements, movement)
+	return movement, nil
+}
+
+type fakeOrders struct {
+	repository.Order
+	db *fakeDB
+}
+
+func (r fakeOrders) CreateOrder(_ context.Context, order model.Order) (model.Order, error) {
+	order.ID = uuid.New()
+	order.CreatedAt = time.Now()
+	order.UpdatedAt = order.CreatedAt
+	r.db.orders = append(r.db.orders, order)
+	return order, nil
+}
+
+func (r fakeOrders) AddOrderItem(_ context.Context, item model.OrderItem) (uuid.UUID, error) {
+	item.ID = uuid.New()
+	r.db.orderItems = append(r.db.orderItems, item)
+	return item.ID, nil
+}
+
+// stockOf возвращает доступный и зарезервированный остаток товара.
+func (db *fakeDB) stockOf(productID uuid.UUID) (available, reserved int) {
+	product := db.products[productID]
+	return product.AvailableStock, product.ReservedStock
+}
+
+// checkDomainError проверяет вид ошибки предметной области и ключ её сообщения.
+func checkDomainError(t *testing.T, err error, kind error, key string) {
+	t.Helper()
+
+	var domainErr *domain.Error
+	if !errors.Is(err, kind) || !errors.As(err, &domainErr) || domainErr.Key != key {
+		t.Fatalf("err = %v, want %v with key %s", err, kind, key)
+	}
+}
@@ -6,31 +6,52 @@ import (
 	"github.com/google/uuid"
 	"src/internal/repository"
 	"src/internal/repository/model"
+	"src/internal/tracing"
 )
 
 type ImageService struct {
 	repo repository.Image
+	tx   repository.Transaction
 }
 
-func NewImageService(repo repository.Image) *ImageService {
-	return &ImageService{repo: repo}
+func NewImageService(repo repository.Image, tx repository.Transaction) *ImageService {
+	return &ImageService{
+		repo: repo,
+		tx:   tx,
+	}
 }
 
 func (s *ImageService) CreateImage(ctx context.Context, image model.Image, productID uuid.UUID) (uuid.UUID, error) {
-	id, err := s.repo.AddImage(ctx, image)
-	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении изображения: %w", err)
-	}
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var id uuid.UUID
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = s.repo.AddImage(ctx, image)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении изображения: %w", err)
+		}
 
-	err = s.repo.AddImageToProduct(ctx, productID, id)
+		err = s.repo.AddImageToProduct(ctx, productID, id)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении изображения в продукт: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении изображения в продукт: %w", err)
+		return uuid.Nil, err
 	}
 
 	return id, nil
 }
 
 func (s *ImageService) UpdateImage(ctx context.Context, image model.Image, imageID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	err := s.repo.UploadImage(ctx, image, imageID)
 	if err != nil {
 		return fmt.Errorf("ошибка при изменение изображения: %w", err)
@@ -40,20 +61,28 @@ func (s *ImageService) UpdateImage(ctx context.Context, image model.Image, image
 }
 
 func (s *ImageService) DeleteImage(ctx context.Context, imageID uuid.UUID) error {
-	err := s.repo.DeleteImage(ctx, imageID)
-	if err != nil {
-		return fmt.Errorf("ошибка при изменение изображения: %w", err)
-	}
-
-	err = s.repo.DeleteImageIdFromProduct(ctx, imageID)
-	if err != nil {
-		return fmt.Errorf("ошибка при удалении image_id из product: %w", err)
-	}
-
-	return nil
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.repo.DeleteImageIdFromProduct(ctx, imageID)
+		if err != nil {
+			return fmt.Errorf("ошибка при удалении image_id из product: %w", err)
+		}
+
+		err = s.repo.DeleteImage(ctx, imageID)
+		if err != nil {
+			return fmt.Errorf("ошибка при удалении изображения: %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (s *ImageService) GetImageByProductId(ctx context.Context, productID uuid.UUID) (model.Image, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	imageId, err := s.repo.GetImageIdByProductId(ctx, productID)
 	if err != nil {
 		return model.Image{}, fmt.Errorf("ошибка при запросе id из продукта: %w", err)
@@ -68,6 +97,9 @@ func (s *ImageService) GetImageByProductId(ctx context.Context, productID uuid.U
 }
 
 func (s *ImageService) GetImageById(ctx context.Context, imageID uuid.UUID) (model.Image, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	image, err := s.repo.GetImageById(ctx, imageID)
 	if err != nil {
 		return model.Image{}, fmt.Errorf("ошибка при получении изображения: %w", err)
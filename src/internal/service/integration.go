@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+)
+
+// SupplierIntegrationService выполняет операции поставщика по API-ключу. Каждая операция
+// допускается только для товаров, у которых supplier_id совпадает с поставщиком ключа.
+type SupplierIntegrationService struct {
+	repoProduct repository.Product
+	stock       Stock
+	tx          repository.Transaction
+}
+
+func NewSupplierIntegrationService(repoProduct repository.Product, stock Stock, tx repository.Transaction) *SupplierIntegrationService {
+	return &SupplierIntegrationService{
+		repoProduct: repoProduct,
+		stock:       stock,
+		tx:          tx,
+	}
+}
+
+func (s *SupplierIntegrationService) GetSupplierProducts(ctx context.Context, supplierID uuid.UUID) ([]model.Product, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	products, err := s.repoProduct.GetProductsBySupplierID(ctx, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении товаров поставщика: %w", err)
+	}
+
+	return products, nil
+}
+
+func (s *SupplierIntegrationService) RestockSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID,
+	quantity int, actor string) (int, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var stock int
+
+	err := s.withSupplierProduct(ctx, supplierID, productID, func(ctx context.Context) error {
+		var err error
+		stock, err = s.stock.Restock(ctx, productID, quantity, actor)
+		return err
+	})
+
+	return stock, err
+}
+
+func (s *SupplierIntegrationService) AdjustSupplierProductStock(ctx context.Context, supplierID, productID uuid.UUID,
+	delta int, actor string) (int, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var stock int
+
+	err := s.withSupplierProduct(ctx, supplierID, productID, func(ctx context.Context) error {
+		var err error
+		stock, err = s.stock.AdjustStock(ctx, productID, delta, actor)
+		return err
+	})
+
+	return stock, err
+}
+
+// UpdateSupplierProductPrice меняет цену товара поставщика, если товар не менялся с версии version.
+func (s *SupplierIntegrationService) UpdateSupplierProductPrice(ctx context.Context, supplierID, productID uuid.UUID, price float64, version int) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if price < 0 {
+		return domain.Invalid("price", "product.price_negative")
+	}
+
+	return s.withSupplierProduct(ctx, supplierID, productID, func(ctx context.Context) error {
+		return s.repoProduct.UpdateProductPrice(ctx, productID, price, version)
+	})
+}
+
+// withSupplierProduct блокирует товар поставщика и выполняет fn в той же транзакции,
+// чтобы товар не сменил поставщика между проверкой и изменением.
+func (s *SupplierIntegrationService) withSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID,
+	fn func(ctx context.Context) error) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repoProduct.LockSupplierProduct(ctx, supplierID, productID); err != nil {
+			return err
+		}
+
+		return fn(ctx)
+	})
+}
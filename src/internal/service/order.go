@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"sort"
+	"src/internal/domain"
+	"src/internal/metrics"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+)
+
+// orderTransitions описывает допустимые переходы между статусами заказа.
+var orderTransitions = map[string][]string{
+	model.OrderStatusCreated: {model.OrderStatusPaid, model.OrderStatusCancelled},
+	model.OrderStatusPaid:    {model.OrderStatusShipped, model.OrderStatusCancelled},
+}
+
+type OrderService struct {
+	repoOrder   repository.Order
+	repoProduct repository.Product
+	repoStock   repository.Stock
+	tx          repository.Transaction
+}
+
+func NewOrderService(repoOrder repository.Order, repoProduct repository.Product, repoStock repository.Stock,
+	tx repository.Transaction) *OrderService {
+	return &OrderService{
+		repoOrder:   repoOrder,
+		repoProduct: repoProduct,
+		repoStock:   repoStock,
+		tx:          tx,
+	}
+}
+
+func (s *OrderService) Checkout(ctx context.Context, clientID uuid.UUID, items []model.OrderItem) (model.Order, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	items, err := mergeOrderItems(items)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	actor := "client:" + clientID.String()
+
+	var order model.Order
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var total float64
+		for i, item := range items {
+			_, err := moveStock(ctx, s.tx, s.repoProduct, s.repoStock, model.StockMovement{
+				ProductID: item.ProductID,
+				Delta:     -item.Quantity,
+				Reason:    model.StockReasonSale,
+				Actor:     actor,
+			}, 0)
+			if err != nil {
+				return fmt.Errorf("ошибка при списании товара %s: %w", item.ProductID, err)
+			}
+
+			product, err := s.repoProduct.GetProductById(ctx, item.ProductID)
+			if err != nil {
+				return fmt.Errorf("ошибка при получении товара %s: %w", item.ProductID, err)
+			}
+
+			items[i].Price = product.Price
+			total += product.Price * float64(item.Quantity)
+		}
+
+		order, err = s.repoOrder.CreateOrder(ctx, model.Order{
+			ClientID: clientID,
+			Status:   model.OrderStatusCreated,
+			Total:    total,
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка при создании заказа: %w", err)
+		}
+
+		for i := range items {
+			items[i].OrderID = order.ID
+			items[i].ID, err = s.repoOrder.AddOrderItem(ctx, items[i])
+			if err != nil {
+				return fmt.Errorf("ошибка при добавлении позиции заказа: %w", err)
+			}
+		}
+
+		order.Items = items
+		return nil
+	})
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	metrics.StockReduced(len(items))
+	slog.InfoContext(ctx, "order created", "order_id", order.ID, "client_id", clientID, "items", len(items))
+	return order, nil
+}
+
+func (s *OrderService) GetOrderByID(ctx context.Context, orderID uuid.UUID) (model.Order, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	order, err := s.repoOrder.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("ошибка при получении заказа: %w", err)
+	}
+
+	order.Items, err = s.repoOrder.GetOrderItems(ctx, orderID)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("ошибка при получении позиций заказа: %w", err)
+	}
+
+	return order, nil
+}
+
+// orderSortKey задаёт порядок заказов клиента: от новых к старым, затем по id.
+var orderSortKey = sortKey[model.Order]{
+	name:  "created_at",
+	value: func(o model.Order) string { return formatCursorTime(o.CreatedAt) },
+	id:    func(o model.Order) uuid.UUID { return o.ID },
+	valid: timeValue,
+}
+
+// GetClientOrders возвращает страницу заказов клиента, начиная с последних, и курсор следующей страницы.
+func (s *OrderService) GetClientOrders(ctx context.Context, clientID uuid.UUID, page model.Page) ([]model.Order, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if err := orderSortKey.check(page, true); err != nil {
+		return nil, nil, err
+	}
+
+	orders, err := s.repoOrder.GetOrdersByClientID(ctx, clientID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при получении списка заказов: %w", err)
+	}
+
+	orders, next := orderSortKey.next(orders, page, true)
+	return orders, next, nil
+}
+
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status string, actor string) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if !isOrderStatus(status) {
+		return domain.New(domain.ErrValidation, "order.unknown_status", status)
+	}
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.repoOrder.GetOrderByID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении заказа: %w", err)
+		}
+
+		if !canTransition(order.Status, status) {
+			return domain.New(domain.ErrConflict, "order.invalid_transition", order.Status, status)
+		}
+
+		err = s.repoOrder.UpdateOrderStatus(ctx, orderID, order.Status, status)
+		if err != nil {
+			return fmt.Errorf("ошибка при изменении статуса заказа: %w", err)
+		}
+
+		if status != model.OrderStatusCancelled {
+			return nil
+		}
+
+		items, err := s.repoOrder.GetOrderItems(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении позиций заказа: %w", err)
+		}
+
+		for _, item := range items {
+			_, err := moveStock(ctx, s.tx, s.repoProduct, s.repoStock, model.StockMovement{
+				ProductID: item.ProductID,
+				Delta:     item.Quantity,
+				Reason:    model.StockReasonReturn,
+				Actor:     actor,
+			}, 0)
+			if err != nil {
+				return fmt.Errorf("ошибка при возврате товара %s на склад: %w", item.ProductID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func isOrderStatus(status string) bool {
+	switch status {
+	case model.OrderStatusCreated, model.OrderStatusPaid, model.OrderStatusShipped, model.OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func canTransition(from, to string) bool {
+	for _, allowed := range orderTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeOrderItems объединяет повторяющиеся товары и сортирует позиции по ID товара,
+// чтобы параллельные заказы блокировали строки product в одном порядке.
+func mergeOrderItems(items []model.OrderItem) ([]model.OrderItem, error) {
+	if len(items) == 0 {
+		return nil, domain.New(domain.ErrValidation, "order.empty")
+	}
+
+	quantities := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, domain.New(domain.ErrValidation, "order.item_quantity", item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	merged := make([]model.OrderItem, 0, len(quantities))
+	for productID, quantity := range quantities {
+		merged = append(merged, model.OrderItem{ProductID: productID, Quantity: quantity})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return bytes.Compare(merged[i].ProductID[:], merged[j].ProductID[:]) < 0
+	})
+
+	return merged, nil
+}
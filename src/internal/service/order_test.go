@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository/model"
+	"testing"
+	"time"
+)
+
+func newTestOrderService(db *fakeDB) *OrderService {
+	return NewOrderService(fakeOrders{db: db}, fakeProducts{db: db}, fakeStock{db: db}, fakeTx{db: db})
+}
+
+func TestCheckout(t *testing.T) {
+	kettle := model.Product{ID: uuid.New(), Name: "Чайник", Price: 1500, AvailableStock: 5, Version: 1}
+	mug := model.Product{ID: uuid.New(), Name: "Кружка", Price: 250.5, AvailableStock: 10, ReservedStock: 2, Version: 1}
+	db := newFakeDB(kettle, mug)
+	clientID := uuid.New()
+
+	order, err := newTestOrderService(db).Checkout(context.Background(), clientID, []model.OrderItem{
+		{ProductID: kettle.ID, Quantity: 2},
+		{ProductID: mug.ID, Quantity: 3},
+		{ProductID: kettle.ID, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+
+	if order.Status != model.OrderStatusCreated || order.ClientID != clientID {
+		t.Fatalf("order = %+v", order)
+	}
+	if want := 3*1500 + 3*250.5; order.Total != want {
+		t.Fatalf("total = %v, want %v", order.Total, want)
+	}
+	if len(order.Items) != 2 || len(db.orderItems) != 2 {
+		t.Fatalf("items = %+v, want two merged lines", order.Items)
+	}
+	if available, _ := db.stockOf(kettle.ID); available != 2 {
+		t.Fatalf("kettle stock = %d, want 2", available)
+	}
+	if available, reserved := db.stockOf(mug.ID); available != 7 || reserved != 2 {
+		t.Fatalf("mug stock = %d/%d, want 7/2", available, reserved)
+	}
+	if len(db.movements) != 2 {
+		t.Fatalf("movements = %d, want 2", len(db.movements))
+	}
+	for _, movement := range db.movements {
+		if movement.Reason != model.StockReasonSale || movement.Actor != "client:"+clientID.String() {
+			t.Fatalf("movement = %+v", movement)
+		}
+	}
+	if db.commits != 1 {
+		t.Fatalf("commits = %d, want 1: вложенные транзакции не фиксируются отдельно", db.commits)
+	}
+}
+
+// TestCheckoutRollback проверяет, что заказ оформляется целиком или не оформляется вовсе:
+// если одну позицию не удалось списать, уже списанные позиции возвращаются на склад.
+func TestCheckoutRollback(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		second   model.Product
+		quantity int
+		wantErr  error
+	}{
+		{
+			name:     "insufficient stock",
+			second:   model.Product{Name: "Кружка", Price: 250, AvailableStock: 3, Version: 1},
+			quantity: 4,
+			wantErr:  domain.ErrInsufficientStock,
+		},
+		{
+			name:     "stock held by reservations",
+			second:   model.Product{Name: "Кружка", Price: 250, AvailableStock: 3, ReservedStock: 2, Version: 1},
+			quantity: 2,
+			wantErr:  domain.ErrInsufficientStock,
+		},
+		{
+			name:     "deleted product",
+			second:   model.Product{Name: "Кружка", Price: 250, AvailableStock: 10, Version: 1, DeletedAt: &deletedAt},
+			quantity: 1,
+			wantErr:  domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Позиции списываются в порядке ID товара, поэтому первый товар получает
+			// наименьший ID и успевает списаться до ошибки на втором.
+			first := model.Product{ID: uuid.UUID{0x01}, Name: "Чайник", Price: 1500, AvailableStock: 5, Version: 1}
+			second := tt.second
+			second.ID = uuid.UUID{0x02}
+			db := newFakeDB(first, second)
+
+			_, err := newTestOrderService(db).Checkout(context.Background(), uuid.New(), []model.OrderItem{
+				{ProductID: second.ID, Quantity: tt.quantity},
+				{ProductID: first.ID, Quantity: 2},
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if db.rollbacks != 1 || db.commits != 0 {
+				t.Fatalf("commits = %d, rollbacks = %d, want 0 and 1", db.commits, db.rollbacks)
+			}
+			if db.products[first.ID] != first || db.products[second.ID] != second {
+				t.Fatalf("остатки изменились после отката: %+v", db.products)
+			}
+			if len(db.movements) != 0 || len(db.orders) != 0 || len(db.orderItems) != 0 {
+				t.Fatalf("после отката остались записи: movements=%d orders=%d items=%d",
+					len(db.movements), len(db.orders), len(db.orderItems))
+			}
+		})
+	}
+}
+
+func TestCheckoutValidation(t *testing.T) {
+	productID := uuid.New()
+
+	tests := []struct {
+		name    string
+		items   []model.OrderItem
+		wantKey string
+	}{
+		{name: "empty order", wantKey: "order.empty"},
+		{name: "zero quantity", items: []model.OrderItem{{ProductID: productID}}, wantKey: "order.item_quantity"},
+		{name: "negative quantity", items: []model.OrderItem{{ProductID: productID, Quantity: -1}}, wantKey: "order.item_quantity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeDB(model.Product{ID: productID, AvailableStock: 5})
+
+			_, err := newTestOrderService(db).Checkout(context.Background(), uuid.New(), tt.items)
+
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrValidation) || domainErr.Key != tt.wantKey {
+				t.Fatalf("err = %v, want validation error %s", err, tt.wantKey)
+			}
+			if db.commits+db.rollbacks != 0 {
+				t.Fatal("транзакция открыта для некорректного заказа")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository/model"
+	"strconv"
+	"time"
+)
+
+// maxPageLimit ограничивает размер одной страницы любого списка.
+const maxPageLimit = 100
+
+// sortKey описывает ключ сортировки списка: как получить из строки значение для курсора
+// и как проверить значение пришедшего курсора, чтобы подделанный курсор не дошёл до запроса.
+type sortKey[T any] struct {
+	name  string
+	value func(T) string
+	id    func(T) uuid.UUID
+	valid func(string) bool
+}
+
+// check проверяет размер страницы и то, что курсор выдан для того же порядка списка.
+func (k sortKey[T]) check(page model.Page, desc bool) error {
+	if page.Limit < 1 || page.Limit > maxPageLimit {
+		return domain.Invalid("limit", "request.limit_range", 1, maxPageLimit)
+	}
+
+	cursor := page.After
+	if cursor != nil && (cursor.Sort != k.name || cursor.Desc != desc || !k.valid(cursor.Value)) {
+		return domain.Invalid("cursor", "request.cursor_mismatch")
+	}
+
+	return nil
+}
+
+// next отбрасывает лишнюю строку, которую репозиторий читает сверх лимита, и возвращает
+// курсор следующей страницы. На последней странице курсор равен nil.
+func (k sortKey[T]) next(items []T, page model.Page, desc bool) ([]T, *model.Cursor) {
+	if len(items) <= page.Limit {
+		return items, nil
+	}
+
+	items = items[:page.Limit]
+	last := items[len(items)-1]
+	return items, &model.Cursor{Sort: k.name, Desc: desc, Value: k.value(last), ID: k.id(last)}
+}
+
+func anyValue(string) bool { return true }
+
+func numberValue(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+func integerValue(value string) bool {
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+func timeValue(value string) bool {
+	_, err := time.Parse(time.RFC3339Nano, value)
+	return err == nil
+}
+
+func formatCursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
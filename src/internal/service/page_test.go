@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository/model"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type pageItem struct {
+	id    uuid.UUID
+	price float64
+}
+
+var priceKey = sortKey[pageItem]{
+	name:  "price",
+	value: func(item pageItem) string { return strconv.FormatFloat(item.price, 'f', -1, 64) },
+	id:    func(item pageItem) uuid.UUID { return item.id },
+	valid: numberValue,
+}
+
+func TestSortKeyCheck(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		key     sortKey[pageItem]
+		page    model.Page
+		desc    bool
+		wantKey string
+	}{
+		{name: "first page", key: priceKey, page: model.Page{Limit: 20}},
+		{name: "smallest page", key: priceKey, page: model.Page{Limit: 1}},
+		{name: "largest page", key: priceKey, page: model.Page{Limit: maxPageLimit}},
+		{name: "empty page", key: priceKey, page: model.Page{Limit: 0}, wantKey: "request.limit_range"},
+		{name: "negative limit", key: priceKey, page: model.Page{Limit: -5}, wantKey: "request.limit_range"},
+		{name: "page too large", key: priceKey, page: model.Page{Limit: maxPageLimit + 1}, wantKey: "request.limit_range"},
+		{
+			name: "matching cursor",
+			key:  priceKey,
+			page: model.Page{Limit: 20, After: &model.Cursor{Sort: "price", Value: "10.5", ID: id}},
+		},
+		{
+			name: "matching descending cursor",
+			key:  priceKey,
+			page: model.Page{Limit: 20, After: &model.Cursor{Sort: "price", Desc: true, Value: "10.5", ID: id}},
+			desc: true,
+		},
+		{
+			name:    "cursor from another sort",
+			key:     priceKey,
+			page:    model.Page{Limit: 20, After: &model.Cursor{Sort: "name", Value: "10.5", ID: id}},
+			wantKey: "request.cursor_mismatch",
+		},
+		{
+			name:    "cursor from the opposite direction",
+			key:     priceKey,
+			page:    model.Page{Limit: 20, After: &model.Cursor{Sort: "price", Desc: true, Value: "10.5", ID: id}},
+			wantKey: "request.cursor_mismatch",
+		},
+		{
+			name:    "tampered value",
+			key:     priceKey,
+			page:    model.Page{Limit: 20, After: &model.Cursor{Sort: "price", Value: "1; DROP TABLE product", ID: id}},
+			wantKey: "request.cursor_mismatch",
+		},
+		{
+			name: "time value",
+			key:  sortKey[pageItem]{name: "date", valid: timeValue},
+			page: model.Page{Limit: 20, After: &model.Cursor{Sort: "date", Value: "2026-01-01T10:00:00.5Z", ID: id}},
+		},
+		{
+			name:    "tampered time value",
+			key:     sortKey[pageItem]{name: "date", valid: timeValue},
+			page:    model.Page{Limit: 20, After: &model.Cursor{Sort: "date", Value: "yesterday", ID: id}},
+			wantKey: "request.cursor_mismatch",
+		},
+		{
+			name:    "tampered integer value",
+			key:     sortKey[pageItem]{name: "stock", valid: integerValue},
+			page:    model.Page{Limit: 20, After: &model.Cursor{Sort: "stock", Value: "1.5", ID: id}},
+			wantKey: "request.cursor_mismatch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.check(tt.page, tt.desc)
+
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("check: %v", err)
+				}
+				return
+			}
+
+			var domainErr *domain.Error
+			if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("err = %v, want validation error", err)
+			}
+			if domainErr.Key != tt.wantKey {
+				t.Fatalf("key = %s, want %s", domainErr.Key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestSortKeyNext(t *testing.T) {
+	items := make([]pageItem, 4)
+	for i := range items {
+		items[i] = pageItem{id: uuid.New(), price: float64(i) + 0.5}
+	}
+
+	tests := []struct {
+		name       string
+		items      []pageItem
+		limit      int
+		desc       bool
+		wantLen    int
+		wantCursor *model.Cursor
+	}{
+		{name: "empty", items: nil, limit: 3, wantLen: 0},
+		{name: "shorter than limit", items: items[:2], limit: 3, wantLen: 2},
+		{name: "exactly limit", items: items[:3], limit: 3, wantLen: 3},
+		{
+			name:       "one extra row",
+			items:      items,
+			limit:      3,
+			wantLen:    3,
+			wantCursor: &model.Cursor{Sort: "price", Value: "2.5", ID: items[2].id},
+		},
+		{
+			name:       "descending",
+			items:      items,
+			limit:      1,
+			desc:       true,
+			wantLen:    1,
+			wantCursor: &model.Cursor{Sort: "price", Desc: true, Value: "0.5", ID: items[0].id},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cursor := priceKey.next(tt.items, model.Page{Limit: tt.limit}, tt.desc)
+
+			if len(got) != tt.wantLen {
+				t.Fatalf("len = %d, want %d", len(got), tt.wantLen)
+			}
+			switch {
+			case tt.wantCursor == nil && cursor != nil:
+				t.Fatalf("cursor = %+v, want nil", *cursor)
+			case tt.wantCursor != nil && (cursor == nil || *cursor != *tt.wantCursor):
+				t.Fatalf("cursor = %+v, want %+v", cursor, *tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestFormatCursorTime(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	value := formatCursorTime(time.Date(2026, 1, 1, 3, 0, 0, 500, moscow))
+
+	if value != "2026-01-01T00:00:00.0000005Z" {
+		t.Fatalf("value = %s", value)
+	}
+	if !timeValue(value) {
+		t.Fatalf("timeValue(%s) = false", value)
+	}
+}
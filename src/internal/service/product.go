@@ -4,32 +4,99 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"log/slog"
+	"src/internal/domain"
+	"src/internal/metrics"
 	"src/internal/repository"
 	"src/internal/repository/model"
+	"src/internal/tracing"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+// maxSearchQueryLength ограничивает длину поискового запроса в символах.
+const maxSearchQueryLength = 200
+
 type ProductService struct {
-	repo repository.Product
+	repo      repository.Product
+	repoStock repository.Stock
+	tx        repository.Transaction
 }
 
-func NewProductService(repo repository.Product) *ProductService {
-	return &ProductService{repo: repo}
+func NewProductService(repo repository.Product, repoStock repository.Stock, tx repository.Transaction) *ProductService {
+	return &ProductService{
+		repo:      repo,
+		repoStock: repoStock,
+		tx:        tx,
+	}
 }
 
-func (s *ProductService) CreateProduct(ctx context.Context, product model.Product) (uuid.UUID, error) {
-	id, err := s.repo.CreateProduct(ctx, product)
+func (s *ProductService) CreateProduct(ctx context.Context, product model.Product, actor string) (uuid.UUID, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var id uuid.UUID
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		initialStock := product.AvailableStock
+		product.AvailableStock = 0
+
+		var err error
+		id, err = s.repo.CreateProduct(ctx, product)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении товара: %w", err)
+		}
+
+		if initialStock == 0 {
+			return nil
+		}
+
+		_, err = moveStock(ctx, s.tx, s.repo, s.repoStock, model.StockMovement{
+			ProductID: id,
+			Delta:     initialStock,
+			Reason:    model.StockReasonRestock,
+			Actor:     actor,
+		}, 0)
+		return err
+	})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении товара: %w", err)
+		return uuid.Nil, err
 	}
 
+	metrics.ProductCreated()
+	slog.InfoContext(ctx, "product created", "product_id", id, "actor", actor)
 	return id, nil
 }
 
-func (s *ProductService) ReduceStock(ctx context.Context, productID uuid.UUID, quantity int) error {
-	return s.repo.ReduceStock(ctx, productID, quantity)
+// ReduceStock списывает проданный товар со склада, если он не менялся с версии version.
+func (s *ProductService) ReduceStock(ctx context.Context, productID uuid.UUID, quantity, version int, actor string) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if quantity <= 0 {
+		return domain.New(domain.ErrValidation, "stock.reduce_quantity")
+	}
+
+	_, err := moveStock(ctx, s.tx, s.repo, s.repoStock, model.StockMovement{
+		ProductID: productID,
+		Delta:     -quantity,
+		Reason:    model.StockReasonSale,
+		Actor:     actor,
+	}, version)
+	if err != nil {
+		return err
+	}
+
+	metrics.StockReduced(1)
+	slog.InfoContext(ctx, "stock reduced", "product_id", productID, "quantity", quantity, "actor", actor)
+	return nil
 }
 
 func (s *ProductService) GetProductById(ctx context.Context, productID uuid.UUID) (model.Product, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	product, err := s.repo.GetProductById(ctx, productID)
 	if err != nil {
 		return model.Product{}, fmt.Errorf("ошибка при получении товара: %w", err)
@@ -38,20 +105,141 @@ func (s *ProductService) GetProductById(ctx context.Context, productID uuid.UUID
 	return product, nil
 }
 
-func (s *ProductService) GetProductList(ctx context.Context) ([]model.Product, error) {
-	users, err := s.repo.GetProductList(ctx)
+// productSortKeys перечисляет допустимые поля сортировки списка товаров.
+var productSortKeys = map[string]sortKey[model.Product]{
+	model.ProductSortName: {
+		name:  model.ProductSortName,
+		value: func(p model.Product) string { return p.Name },
+		id:    productID,
+		valid: anyValue,
+	},
+	model.ProductSortPrice: {
+		name:  model.ProductSortPrice,
+		value: func(p model.Product) string { return strconv.FormatFloat(p.Price, 'f', -1, 64) },
+		id:    productID,
+		valid: numberValue,
+	},
+	model.ProductSortStock: {
+		name:  model.ProductSortStock,
+		value: func(p model.Product) string { return strconv.Itoa(p.AvailableStock) },
+		id:    productID,
+		valid: integerValue,
+	},
+	model.ProductSortLastUpdateDate: {
+		name:  model.ProductSortLastUpdateDate,
+		value: func(p model.Product) string { return formatCursorTime(p.LastUpdateDate) },
+		id:    productID,
+		valid: timeValue,
+	},
+}
+
+func productID(p model.Product) uuid.UUID { return p.ID }
+
+// GetProductList возвращает страницу товаров по фильтру, общее число подходящих товаров
+// и курсор следующей страницы.
+func (s *ProductService) GetProductList(ctx context.Context, filter model.ProductFilter) ([]model.Product, int, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if filter.Sort == "" {
+		filter.Sort = model.ProductSortName
+	}
+	key, ok := productSortKeys[filter.Sort]
+	if !ok {
+		return nil, 0, nil, domain.Invalid("sort", "validation.oneof", strings.Join(model.ProductSortFields, " "))
+	}
+	if err := key.check(filter.Page, filter.Desc); err != nil {
+		return nil, 0, nil, err
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, 0, nil, domain.Invalid("max_price", "product.price_range_invalid")
+	}
+
+	products, total, err := s.repo.GetProductList(ctx, filter)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("ошибка при получении списка товаров: %w", err)
+	}
+
+	products, next := key.next(products, filter.Page, filter.Desc)
+	return products, total, next, nil
+}
+
+// productSearchKey задаёт порядок результатов поиска: по убыванию релевантности.
+var productSearchKey = sortKey[model.ProductMatch]{
+	name:  "rank",
+	value: func(m model.ProductMatch) string { return strconv.FormatFloat(m.Rank, 'g', -1, 64) },
+	id:    func(m model.ProductMatch) uuid.UUID { return m.ID },
+	valid: numberValue,
+}
+
+// SearchProducts ищет товары по названию и категории и возвращает страницу результатов
+// по убыванию релевантности и курсор следующей страницы.
+func (s *ProductService) SearchProducts(ctx context.Context, search model.ProductSearch) ([]model.ProductMatch, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Query == "" {
+		return nil, nil, domain.Invalid("q", "validation.required")
+	}
+	if utf8.RuneCountInString(search.Query) > maxSearchQueryLength {
+		return nil, nil, domain.Invalid("q", "validation.max", maxSearchQueryLength)
+	}
+	if err := productSearchKey.check(search.Page, true); err != nil {
+		return nil, nil, err
+	}
+
+	matches, err := s.repo.SearchProducts(ctx, search)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при поиске товаров: %w", err)
+	}
+
+	matches, next := productSearchKey.next(matches, search.Page, true)
+	return matches, next, nil
+}
+
+// UpdateProduct изменяет название, категорию, цену и поставщика товара и возвращает
+// его новое состояние с обновлённой датой изменения.
+func (s *ProductService) UpdateProduct(ctx context.Context, product model.Product) (model.Product, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if product.Price < 0 {
+		return model.Product{}, domain.Invalid("price", "product.price_negative")
+	}
+
+	updated, err := s.repo.UpdateProduct(ctx, product)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении списка товаров: %w", err)
+		return model.Product{}, fmt.Errorf("ошибка при изменении товара: %w", err)
 	}
 
-	return users, nil
+	slog.InfoContext(ctx, "product updated", "product_id", updated.ID, "supplier_id", updated.SupplierID)
+	return updated, nil
 }
 
-func (s *ProductService) RemoveProduct(ctx context.Context, productID uuid.UUID) error {
-	err := s.repo.DeleteProduct(ctx, productID)
+// RemoveProduct помечает товар удалённым, если он не менялся с версии version.
+func (s *ProductService) RemoveProduct(ctx context.Context, productID uuid.UUID, version int) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	err := s.repo.DeleteProduct(ctx, productID, version)
 	if err != nil {
 		return fmt.Errorf("ошибка при удалении товара: %w", err)
 	}
 
 	return nil
 }
+
+// RestoreProduct восстанавливает удалённый товар.
+func (s *ProductService) RestoreProduct(ctx context.Context, productID uuid.UUID) (model.Product, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	product, err := s.repo.RestoreProduct(ctx, productID)
+	if err != nil {
+		return model.Product{}, fmt.Errorf("ошибка при восстановлении товара: %w", err)
+	}
+
+	slog.InfoContext(ctx, "product restored", "product_id", product.ID)
+	return product, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+	"time"
+)
+
+// PurgeService окончательно стирает записи, удалённые раньше срока хранения.
+type PurgeService struct {
+	repoProduct  repository.Product
+	repoSupplier repository.Supplier
+	repoUser     repository.User
+	repoAddress  repository.Address
+	tx           repository.Transaction
+	retention    time.Duration
+}
+
+func NewPurgeService(repoProduct repository.Product, repoSupplier repository.Supplier, repoUser repository.User,
+	repoAddress repository.Address, tx repository.Transaction, retention time.Duration) *PurgeService {
+	return &PurgeService{
+		repoProduct:  repoProduct,
+		repoSupplier: repoSupplier,
+		repoUser:     repoUser,
+		repoAddress:  repoAddress,
+		tx:           tx,
+		retention:    retention,
+	}
+}
+
+// PurgeDeleted стирает товары, поставщиков и клиентов, удалённых раньше срока хранения,
+// вместе с адресами поставщиков и клиентов. Товары стираются первыми, чтобы в том же проходе
+// можно было стереть поставщиков, у которых не осталось товаров.
+func (s *PurgeService) PurgeDeleted(ctx context.Context) (model.PurgeResult, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var result model.PurgeResult
+
+	products, err := s.repoProduct.PurgeProducts(ctx, s.retention)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при очистке товаров: %w", err)
+	}
+	result.Products = products
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		purged, addressIDs, err := s.repoSupplier.PurgeSuppliers(ctx, s.retention)
+		if err != nil {
+			return err
+		}
+		result.Suppliers = purged
+		return s.repoAddress.DeleteAddresses(ctx, addressIDs)
+	})
+	if err != nil {
+		return result, fmt.Errorf("ошибка при очистке поставщиков: %w", err)
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		purged, addressIDs, err := s.repoUser.PurgeUsers(ctx, s.retention)
+		if err != nil {
+			return err
+		}
+		result.Users = purged
+		return s.repoAddress.DeleteAddresses(ctx, addressIDs)
+	})
+	if err != nil {
+		return result, fmt.Errorf("ошибка при очистке пользователей: %w", err)
+	}
+
+	return result, nil
+}
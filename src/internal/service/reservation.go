@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/metrics"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+	"time"
+)
+
+// expireBatchSize ограничивает число резервов, снимаемых за одну транзакцию.
+const expireBatchSize = 100
+
+type ReservationService struct {
+	repoReservation repository.Reservation
+	repoProduct     repository.Product
+	repoStock       repository.Stock
+	tx              repository.Transaction
+	defaultTTL      time.Duration
+	maxTTL          time.Duration
+}
+
+func NewReservationService(repoReservation repository.Reservation, repoProduct repository.Product,
+	repoStock repository.Stock, tx repository.Transaction, defaultTTL, maxTTL time.Duration) *ReservationService {
+	return &ReservationService{
+		repoReservation: repoReservation,
+		repoProduct:     repoProduct,
+		repoStock:       repoStock,
+		tx:              tx,
+		defaultTTL:      defaultTTL,
+		maxTTL:          maxTTL,
+	}
+}
+
+func (s *ReservationService) Reserve(ctx context.Context, productID uuid.UUID, quantity int, ttl time.Duration) (model.Reservation, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if quantity <= 0 {
+		return model.Reservation{}, domain.New(domain.ErrValidation, "reservation.quantity")
+	}
+
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+	if s.maxTTL > 0 && ttl > s.maxTTL {
+		return model.Reservation{}, domain.New(domain.ErrValidation, "reservation.ttl_exceeded", s.maxTTL)
+	}
+
+	var reservation model.Reservation
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.repoProduct.ReserveStock(ctx, productID, quantity)
+		if err != nil {
+			return err
+		}
+
+		reservation, err = s.repoReservation.CreateReservation(ctx, model.Reservation{
+			ProductID: productID,
+			Quantity:  quantity,
+			Status:    model.ReservationStatusActive,
+		}, ttl)
+		if err != nil {
+			return fmt.Errorf("ошибка при создании резерва: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return model.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+func (s *ReservationService) GetReservationByID(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	reservation, err := s.repoReservation.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return model.Reservation{}, fmt.Errorf("ошибка при получении резерва: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// ConfirmReservation снимает резерв и списывает зарезервированное количество со склада.
+func (s *ReservationService) ConfirmReservation(ctx context.Context, reservationID uuid.UUID, actor string) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		reservation, err := s.activeReservation(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Expired {
+			return domain.New(domain.ErrConflict, "reservation.expired")
+		}
+
+		err = s.repoReservation.UpdateReservationStatus(ctx, reservationID, model.ReservationStatusActive,
+			model.ReservationStatusConfirmed)
+		if err != nil {
+			return fmt.Errorf("ошибка при подтверждении резерва: %w", err)
+		}
+
+		err = s.repoProduct.ReleaseStock(ctx, reservation.ProductID, reservation.Quantity)
+		if err != nil {
+			return err
+		}
+
+		_, err = moveStock(ctx, s.tx, s.repoProduct, s.repoStock, model.StockMovement{
+			ProductID: reservation.ProductID,
+			Delta:     -reservation.Quantity,
+			Reason:    model.StockReasonSale,
+			Actor:     actor,
+		}, 0)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.StockReduced(1)
+	return nil
+}
+
+func (s *ReservationService) ReleaseReservation(ctx context.Context, reservationID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		reservation, err := s.activeReservation(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		return s.release(ctx, reservation, model.ReservationStatusReleased)
+	})
+}
+
+// ExpireReservations снимает истёкшие резервы и возвращает их количество.
+func (s *ReservationService) ExpireReservations(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	total := 0
+	for {
+		var expired int
+		err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			reservations, err := s.repoReservation.GetExpiredReservations(ctx, expireBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, reservation := range reservations {
+				if err := s.release(ctx, reservation, model.ReservationStatusExpired); err != nil {
+					return err
+				}
+			}
+
+			expired = len(reservations)
+			return nil
+		})
+		if err != nil {
+			return total, fmt.Errorf("ошибка при снятии истёкших резервов: %w", err)
+		}
+
+		total += expired
+		if expired < expireBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *ReservationService) activeReservation(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error) {
+	reservation, err := s.repoReservation.GetReservationForUpdate(ctx, reservationID)
+	if err != nil {
+		return model.Reservation{}, fmt.Errorf("ошибка при получении резерва: %w", err)
+	}
+
+	if reservation.Status != model.ReservationStatusActive {
+		return model.Reservation{}, domain.New(domain.ErrConflict, "reservation.not_active", reservation.Status)
+	}
+
+	return reservation, nil
+}
+
+func (s *ReservationService) release(ctx context.Context, reservation model.Reservation, status string) error {
+	err := s.repoReservation.UpdateReservationStatus(ctx, reservation.ID, model.ReservationStatusActive, status)
+	if err != nil {
+		return fmt.Errorf("ошибка при снятии резерва: %w", err)
+	}
+
+	return s.repoProduct.ReleaseStock(ctx, reservation.ProductID, reservation.Quantity)
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"testing"
+	"time"
+)
+
+// fakeReservations хранит резервы в fakeDB. Срок резерва сравнивается с текущим временем,
+// как в GetReservationForUpdate и GetExpiredReservations.
+type fakeReservations struct {
+	repository.Reservation
+	db *fakeDB
+}
+
+func (r fakeReservations) CreateReservation(_ context.Context, reservation model.Reservation, ttl time.Duration) (model.Reservation, error) {
+	reservation.ID = uuid.New()
+	reservation.CreatedAt = time.Now()
+	reservation.UpdatedAt = reservation.CreatedAt
+	reservation.ExpiresAt = reservation.CreatedAt.Add(ttl)
+	r.db.reservations[reservation.ID] = reservation
+	return reservation, nil
+}
+
+func (r fakeReservations) GetReservationByID(_ context.Context, reservationID uuid.UUID) (model.Reservation, error) {
+	reservation, ok := r.db.reservations[reservationID]
+	if !ok {
+		return model.Reservation{}, domain.ErrNotFound
+	}
+	return reservation, nil
+}
+
+func (r fakeReservations) GetReservationForUpdate(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error) {
+	reservation, err := r.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return model.Reservation{}, err
+	}
+
+	reservation.Expired = !reservation.ExpiresAt.After(time.Now())
+	return reservation, nil
+}
+
+func (r fakeReservations) UpdateReservationStatus(_ context.Context, reservationID uuid.UUID, from, to string) error {
+	reservation, ok := r.db.reservations[reservationID]
+	if !ok || reservation.Status != from {
+		return domain.New(domain.ErrConflict, "reservation.status_conflict")
+	}
+
+	reservation.Status = to
+	r.db.reservations[reservationID] = reservation
+	return nil
+}
+
+func (r fakeReservations) GetExpiredReservations(_ context.Context, limit int) ([]model.Reservation, error) {
+	var expired []model.Reservation
+	for _, reservation := range r.db.reservations {
+		if len(expired) == limit {
+			break
+		}
+		if reservation.Status == model.ReservationStatusActive && !reservation.ExpiresAt.After(time.Now()) {
+			expired = append(expired, reservation)
+		}
+	}
+	return expired, nil
+}
+
+const (
+	testDefaultTTL = 15 * time.Minute
+	testMaxTTL     = time.Hour
+)
+
+func newTestReservationService(db *fakeDB) *ReservationService {
+	return NewReservationService(fakeReservations{db: db}, fakeProducts{db: db}, fakeStock{db: db}, fakeTx{db: db},
+		testDefaultTTL, testMaxTTL)
+}
+
+// expire переносит срок резерва в прошлое.
+func (db *fakeDB) expire(reservationID uuid.UUID) {
+	reservation := db.reservations[reservationID]
+	reservation.ExpiresAt = time.Now().Add(-time.Second)
+	db.reservations[reservationID] = reservation
+}
+
+func TestReserve(t *testing.T) {
+	product := model.Product{ID: uuid.New(), Name: "Чайник", AvailableStock: 5, ReservedStock: 1, Version: 1}
+	db := newFakeDB(product)
+	service := newTestReservationService(db)
+
+	before := time.Now()
+	reservation, err := service.Reserve(context.Background(), product.ID, 3, 0)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	if reservation.Status != model.ReservationStatusActive || reservation.Quantity != 3 {
+		t.Fatalf("reservation = %+v", reservation)
+	}
+	if reservation.ExpiresAt.Before(before.Add(testDefaultTTL)) {
+		t.Fatalf("expires_at = %v, want default TTL %v", reservation.ExpiresAt, testDefaultTTL)
+	}
+	if available, reserved := db.stockOf(product.ID); available != 5 || reserved != 4 {
+		t.Fatalf("stock = %d/%d, want 5/4", available, reserved)
+	}
+	if len(db.movements) != 0 {
+		t.Fatal("резерв не должен менять остаток и писать журнал движения")
+	}
+
+	// Свободно осталось 5 - 4 = 1.
+	_, err = service.Reserve(context.Background(), product.ID, 2, time.Minute)
+	if !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("err = %v, want %v", err, domain.ErrInsufficientStock)
+	}
+	if _, reserved := db.stockOf(product.ID); reserved != 4 || len(db.reservations) != 1 {
+		t.Fatalf("reserved = %d, reservations = %d after failed reserve", reserved, len(db.reservations))
+	}
+}
+
+func TestReserveValidation(t *testing.T) {
+	product := model.Product{ID: uuid.New(), AvailableStock: 5, Version: 1}
+
+	tests := []struct {
+		name     string
+		quantity int
+		ttl      time.Duration
+		wantKey  string
+	}{
+		{name: "zero quantity", quantity: 0, wantKey: "reservation.quantity"},
+		{name: "negative quantity", quantity: -1, wantKey: "reservation.quantity"},
+		{name: "ttl above maximum", quantity: 1, ttl: testMaxTTL + time.Second, wantKey: "reservation.ttl_exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeDB(product)
+
+			_, err := newTestReservationService(db).Reserve(context.Background(), product.ID, tt.quantity, tt.ttl)
+
+			checkDomainError(t, err, domain.ErrValidation, tt.wantKey)
+			if len(db.reservations) != 0 || db.products[product.ID] != product {
+				t.Fatal("некорректный резерв изменил данные")
+			}
+		})
+	}
+}
+
+func TestConfirmReservation(t *testing.T) {
+	product := model.Product{ID: uuid.New(), Name: "Чайник", AvailableStock: 5, Version: 1}
+	db := newFakeDB(product)
+	service := newTestReservationService(db)
+
+	reservation, err := service.Reserve(context.Background(), product.ID, 2, 0)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	if err := service.ConfirmReservation(context.Background(), reservation.ID, "staff:manager"); err != nil {
+		t.Fatalf("ConfirmReservation: %v", err)
+	}
+
+	if status := db.reservations[reservation.ID].Status; status != model.ReservationStatusConfirmed {
+		t.Fatalf("status = %s, want %s", status, model.ReservationStatusConfirmed)
+	}
+	if available, reserved := db.stockOf(product.ID); available != 3 || reserved != 0 {
+		t.Fatalf("stock = %d/%d, want 3/0", available, reserved)
+	}
+	if len(db.movements) != 1 || db.movements[0].Delta != -2 || db.movements[0].Reason != model.StockReasonSale {
+		t.Fatalf("movements = %+v, want one sale of 2", db.movements)
+	}
+
+	err = service.ConfirmReservation(context.Background(), reservation.ID, "staff:manager")
+	checkDomainError(t, err, domain.ErrConflict, "reservation.not_active")
+	if available, _ := db.stockOf(product.ID); available != 3 || len(db.movements) != 1 {
+		t.Fatal("повторное подтверждение списало товар ещё раз")
+	}
+}
+
+func TestConfirmExpiredReservation(t *testing.T) {
+	product := model.Product{ID: uuid.New(), Name: "Чайник", AvailableStock: 5, Version: 1}
+	db := newFakeDB(product)
+	service := newTestReservationService(db)
+
+	reservation, err := service.Reserve(context.Background(), product.ID, 2, 0)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	db.expire(reservation.ID)
+
+	err = service.ConfirmReservation(context.Background(), reservation.ID, "staff:manager")
+
+	checkDomainError(t, err, domain.ErrConflict, "reservation.expired")
+	if status := db.reservations[reservation.ID].Status; status != model.ReservationStatusActive {
+		t.Fatalf("status = %s, want %s until the sweeper expires it", status, model.ReservationStatusActive)
+	}
+	if available, reserved := db.stockOf(product.ID); available != 5 || reserved != 2 || len(db.movements) != 0 {
+		t.Fatalf("stock = %d/%d, movements = %d after refused confirm", available, reserved, len(db.movements))
+	}
+}
+
+func TestReleaseReservation(t *testing.T) {
+	product := model.Product{ID: uuid.New(), AvailableStock: 5, Version: 1}
+	db := newFakeDB(product)
+	service := newTestReservationService(db)
+
+	reservation, err := service.Reserve(context.Background(), product.ID, 2, 0)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	if err := service.ReleaseReservation(context.Background(), reservation.ID); err != nil {
+		t.Fatalf("ReleaseReservation: %v", err)
+	}
+
+	if status := db.reservations[reservation.ID].Status; status != model.ReservationStatusReleased {
+		t.Fatalf("status = %s, want %s", status, model.ReservationStatusReleased)
+	}
+	if available, reserved := db.stockOf(product.ID); available != 5 || reserved != 0 {
+		t.Fatalf("stock = %d/%d, want 5/0", available, reserved)
+	}
+}
+
+func TestExpireReservations(t *testing.T) {
+	product := model.Product{ID: uuid.New(), AvailableStock: 500, Version: 1}
+	db := newFakeDB(product)
+	service := newTestReservationService(db)
+
+	// Истёкших резервов больше, чем помещается в одну транзакцию.
+	expiredCount := expireBatchSize + 1
+	for range expiredCount {
+		reservation, err := service.Reserve(context.Background(), product.ID, 1, 0)
+		if err != nil {
+			t.Fatalf("Reserve: %v", err)
+		}
+		db.expire(reservation.ID)
+	}
+	active, err := service.Reserve(context.Background(), product.ID, 3, 0)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	expired, err := service.ExpireReservations(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireReservations: %v", err)
+	}
+
+	if expired != expiredCount {
+		t.Fatalf("expired = %d, want %d", expired, expiredCount)
+	}
+	for id, reservation := range db.reservations {
+		want := model.ReservationStatusExpired
+		if id == active.ID {
+			want = model.ReservationStatusActive
+		}
+		if reservation.Status != want {
+			t.Fatalf("reservation %s status = %s, want %s", id, reservation.Status, want)
+		}
+	}
+	if available, reserved := db.stockOf(product.ID); available != 500 || reserved != 3 {
+		t.Fatalf("stock = %d/%d, want 500/3", available, reserved)
+	}
+
+	if expired, err := service.ExpireReservations(context.Background()); err != nil || expired != 0 {
+		t.Fatalf("second run = %d, %v; want 0, nil", expired, err)
+	}
+}
@@ -3,32 +3,45 @@ package service
 import (
 	"context"
 	"github.com/google/uuid"
+	"src/internal/mail"
 	"src/internal/repository"
 	"src/internal/repository/model"
+	"time"
 )
 
 type User interface {
 	AddUser(ctx context.Context, user model.User, address model.Address) (uuid.UUID, error)
-	RemoveUser(ctx context.Context, userID uuid.UUID) error
+	RemoveUser(ctx context.Context, userID uuid.UUID, version int) error
+	GetUserByID(ctx context.Context, userID uuid.UUID) (model.User, error)
+	GetUserAddress(ctx context.Context, userID uuid.UUID) (model.Address, error)
+	UpdateUser(ctx context.Context, user model.User) (model.User, error)
 	GetUsers(ctx context.Context, name, surname string) ([]model.User, error)
-	GetUsersList(ctx context.Context, limit, offset int) ([]model.User, error)
-	UpdateUserAddress(ctx context.Context, userID uuid.UUID, address model.Address) error
+	GetUsersList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.User, *model.Cursor, error)
+	SearchUsers(ctx context.Context, filter model.ClientFilter) ([]model.User, *model.Cursor, error)
+	UpdateUserAddress(ctx context.Context, userID uuid.UUID, address model.Address) (model.Address, error)
+	RestoreUser(ctx context.Context, userID uuid.UUID) (model.User, error)
 }
 
 type Supplier interface {
 	AddSupplier(ctx context.Context, supplier model.Supplier, address model.Address) (uuid.UUID, error)
-	UpdateSupplierAddress(ctx context.Context, SupplierID uuid.UUID, address model.Address) error
-	RemoveSupplier(ctx context.Context, SupplierID uuid.UUID) error
-	GetSuppliersList(ctx context.Context) ([]model.Supplier, error)
+	UpdateSupplier(ctx context.Context, supplier model.Supplier) (model.Supplier, error)
+	GetSupplierAddress(ctx context.Context, supplierID uuid.UUID) (model.Address, error)
+	UpdateSupplierAddress(ctx context.Context, SupplierID uuid.UUID, address model.Address) (model.Address, error)
+	RemoveSupplier(ctx context.Context, SupplierID uuid.UUID, version int) error
+	GetSuppliersList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.Supplier, *model.Cursor, error)
 	GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error)
+	RestoreSupplier(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error)
 }
 
 type Product interface {
-	CreateProduct(ctx context.Context, product model.Product) (uuid.UUID, error)
-	ReduceStock(ctx context.Context, productID uuid.UUID, quantity int) error
+	CreateProduct(ctx context.Context, product model.Product, actor string) (uuid.UUID, error)
+	ReduceStock(ctx context.Context, productID uuid.UUID, quantity, version int, actor string) error
 	GetProductById(ctx context.Context, productID uuid.UUID) (model.Product, error)
-	GetProductList(ctx context.Context) ([]model.Product, error)
-	RemoveProduct(ctx context.Context, productID uuid.UUID) error
+	GetProductList(ctx context.Context, filter model.ProductFilter) ([]model.Product, int, *model.Cursor, error)
+	UpdateProduct(ctx context.Context, product model.Product) (model.Product, error)
+	SearchProducts(ctx context.Context, search model.ProductSearch) ([]model.ProductMatch, *model.Cursor, error)
+	RemoveProduct(ctx context.Context, productID uuid.UUID, version int) error
+	RestoreProduct(ctx context.Context, productID uuid.UUID) (model.Product, error)
 }
 
 type Image interface {
@@ -39,18 +52,114 @@ type Image interface {
 	GetImageById(ctx context.Context, imageID uuid.UUID) (model.Image, error)
 }
 
+type Order interface {
+	Checkout(ctx context.Context, clientID uuid.UUID, items []model.OrderItem) (model.Order, error)
+	GetOrderByID(ctx context.Context, orderID uuid.UUID) (model.Order, error)
+	GetClientOrders(ctx context.Context, clientID uuid.UUID, page model.Page) ([]model.Order, *model.Cursor, error)
+	UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, status string, actor string) error
+}
+
+type Stock interface {
+	Restock(ctx context.Context, productID uuid.UUID, quantity int, actor string) (int, error)
+	AdjustStock(ctx context.Context, productID uuid.UUID, delta int, actor string) (int, error)
+	GetStockMovements(ctx context.Context, productID uuid.UUID, page model.Page) ([]model.StockMovement, *model.Cursor, error)
+}
+
+type Reservation interface {
+	Reserve(ctx context.Context, productID uuid.UUID, quantity int, ttl time.Duration) (model.Reservation, error)
+	GetReservationByID(ctx context.Context, reservationID uuid.UUID) (model.Reservation, error)
+	ConfirmReservation(ctx context.Context, reservationID uuid.UUID, actor string) error
+	ReleaseReservation(ctx context.Context, reservationID uuid.UUID) error
+	ExpireReservations(ctx context.Context) (int, error)
+}
+
+type Auth interface {
+	CreateStaffUser(ctx context.Context, login, password, role string) (uuid.UUID, error)
+	Login(ctx context.Context, login, password string) (model.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error)
+	ParseAccessToken(accessToken string) (model.Principal, error)
+}
+
+type Client interface {
+	RegisterClient(ctx context.Context, user model.User, address model.Address, email, password string) (uuid.UUID, error)
+	LoginClient(ctx context.Context, email, password string) (model.TokenPair, error)
+	VerifyClientEmail(ctx context.Context, token string) error
+	RequestClientPasswordReset(ctx context.Context, email string) error
+	ResetClientPassword(ctx context.Context, token, password string) error
+}
+
+type APIKey interface {
+	CreateAPIKey(ctx context.Context, supplierID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (model.APIKey, string, error)
+	GetSupplierAPIKeys(ctx context.Context, supplierID uuid.UUID, page model.Page) ([]model.APIKey, *model.Cursor, error)
+	RotateAPIKey(ctx context.Context, supplierID, keyID uuid.UUID, expiresAt *time.Time) (model.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, supplierID, keyID uuid.UUID) error
+	AuthenticateAPIKey(ctx context.Context, plainText string) (model.APIKey, error)
+}
+
+type SupplierIntegration interface {
+	GetSupplierProducts(ctx context.Context, supplierID uuid.UUID) ([]model.Product, error)
+	RestockSupplierProduct(ctx context.Context, supplierID, productID uuid.UUID, quantity int, actor string) (int, error)
+	AdjustSupplierProductStock(ctx context.Context, supplierID, productID uuid.UUID, delta int, actor string) (int, error)
+	UpdateSupplierProductPrice(ctx context.Context, supplierID, productID uuid.UUID, price float64, version int) error
+}
+
+type Purge interface {
+	PurgeDeleted(ctx context.Context) (model.PurgeResult, error)
+}
+
+type System interface {
+	GetPoolStats() model.PoolStats
+	CheckDependencies(ctx context.Context) []model.DependencyCheck
+}
+
 type Service struct {
 	User
 	Supplier
 	Product
 	Image
+	Order
+	Stock
+	Reservation
+	Auth
+	Client
+	APIKey
+	SupplierIntegration
+	Purge
+	System
 }
 
-func NewService(repos *repository.Repository) *Service {
+type Config struct {
+	ReservationDefaultTTL time.Duration
+	ReservationMaxTTL     time.Duration
+	JWTSecret             []byte
+	JWTIssuer             string
+	AccessTokenTTL        time.Duration
+	RefreshTokenTTL       time.Duration
+	ClientVerifyTTL       time.Duration
+	ClientResetTTL        time.Duration
+	ClientLinks           ClientLinks
+	HealthCheckTimeout    time.Duration
+	SoftDeleteRetention   time.Duration
+}
+
+func NewService(repos *repository.Repository, schema SchemaVersion, mailer mail.Sender, cfg Config) *Service {
+	tokens := newAccessTokens(cfg.JWTSecret, cfg.JWTIssuer, cfg.AccessTokenTTL)
+	users := NewUserService(repos.User, repos.Address, repos.Transaction)
+	stock := NewStockService(repos.Product, repos.Stock, repos.Transaction)
+
 	return &Service{
-		User:     NewUserService(repos.User, repos.Address),
-		Supplier: NewSupplierService(repos.Supplier, repos.Address),
-		Product:  NewProductService(repos.Product),
-		Image:    NewImageService(repos.Image),
+		User:                users,
+		Supplier:            NewSupplierService(repos.Supplier, repos.Address, repos.Transaction),
+		Product:             NewProductService(repos.Product, repos.Stock, repos.Transaction),
+		Image:               NewImageService(repos.Image, repos.Transaction),
+		Order:               NewOrderService(repos.Order, repos.Product, repos.Stock, repos.Transaction),
+		Stock:               stock,
+		Reservation:         NewReservationService(repos.Reservation, repos.Product, repos.Stock, repos.Transaction, cfg.ReservationDefaultTTL, cfg.ReservationMaxTTL),
+		Auth:                NewAuthService(repos.Staff, repos.Transaction, tokens, cfg.RefreshTokenTTL),
+		Client:              NewClientService(repos.ClientAccount, users, repos.Transaction, tokens, mailer, cfg.ClientLinks, cfg.ClientVerifyTTL, cfg.ClientResetTTL),
+		APIKey:              NewAPIKeyService(repos.APIKey, repos.Supplier, repos.Transaction),
+		SupplierIntegration: NewSupplierIntegrationService(repos.Product, stock, repos.Transaction),
+		Purge:               NewPurgeService(repos.Product, repos.Supplier, repos.User, repos.Address, repos.Transaction, cfg.SoftDeleteRetention),
+		System:              NewSystemService(repos.System, schema, cfg.HealthCheckTimeout),
 	}
 }
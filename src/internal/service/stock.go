@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+)
+
+type StockService struct {
+	repoProduct repository.Product
+	repoStock   repository.Stock
+	tx          repository.Transaction
+}
+
+func NewStockService(repoProduct repository.Product, repoStock repository.Stock, tx repository.Transaction) *StockService {
+	return &StockService{
+		repoProduct: repoProduct,
+		repoStock:   repoStock,
+		tx:          tx,
+	}
+}
+
+func (s *StockService) Restock(ctx context.Context, productID uuid.UUID, quantity int, actor string) (int, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if quantity <= 0 {
+		return 0, domain.New(domain.ErrValidation, "stock.restock_quantity")
+	}
+
+	return moveStock(ctx, s.tx, s.repoProduct, s.repoStock, model.StockMovement{
+		ProductID: productID,
+		Delta:     quantity,
+		Reason:    model.StockReasonRestock,
+		Actor:     actor,
+	}, 0)
+}
+
+func (s *StockService) AdjustStock(ctx context.Context, productID uuid.UUID, delta int, actor string) (int, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if delta == 0 {
+		return 0, domain.New(domain.ErrValidation, "stock.adjust_zero")
+	}
+
+	return moveStock(ctx, s.tx, s.repoProduct, s.repoStock, model.StockMovement{
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    model.StockReasonAdjustment,
+		Actor:     actor,
+	}, 0)
+}
+
+// stockMovementSortKey задаёт порядок журнала движения: от новых записей к старым, затем по id.
+var stockMovementSortKey = sortKey[model.StockMovement]{
+	name:  "created_at",
+	value: func(m model.StockMovement) string { return formatCursorTime(m.CreatedAt) },
+	id:    func(m model.StockMovement) uuid.UUID { return m.ID },
+	valid: timeValue,
+}
+
+// GetStockMovements возвращает страницу журнала движения товара, начиная с последних записей,
+// и курсор следующей страницы.
+func (s *StockService) GetStockMovements(ctx context.Context, productID uuid.UUID, page model.Page) ([]model.StockMovement, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if err := stockMovementSortKey.check(page, true); err != nil {
+		return nil, nil, err
+	}
+
+	movements, err := s.repoStock.GetStockMovements(ctx, productID, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при получении истории движения товара: %w", err)
+	}
+
+	movements, next := stockMovementSortKey.next(movements, page, true)
+	return movements, next, nil
+}
+
+// moveStock изменяет остаток товара и записывает движение в журнал в одной транзакции.
+// Любое изменение available_stock должно проходить через эту функцию. Если version больше
+// нуля, остаток меняется, только пока товар не менялся с этой версии. Возврат принимается
+// и на удалённый товар.
+func moveStock(ctx context.Context, tx repository.Transaction, repoProduct repository.Product,
+	repoStock repository.Stock, movement model.StockMovement, version int) (int, error) {
+	var stock int
+
+	err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if movement.Reason == model.StockReasonReturn {
+			stock, err = repoProduct.ReturnStock(ctx, movement.ProductID, movement.Delta)
+		} else {
+			stock, err = repoProduct.ChangeStock(ctx, movement.ProductID, movement.Delta, version)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = repoStock.AddStockMovement(ctx, movement)
+		if err != nil {
+			return fmt.Errorf("ошибка при записи движения товара: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
@@ -4,83 +4,171 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"log/slog"
 	"src/internal/repository"
 	"src/internal/repository/model"
+	"src/internal/tracing"
 )
 
 type SupplierService struct {
 	repoSupplier repository.Supplier
 	repoAddress  repository.Address
+	tx           repository.Transaction
 }
 
-func NewSupplierService(repoSupplier repository.Supplier, repoAddress repository.Address) *SupplierService {
+func NewSupplierService(repoSupplier repository.Supplier, repoAddress repository.Address, tx repository.Transaction) *SupplierService {
 	return &SupplierService{
 		repoSupplier: repoSupplier,
 		repoAddress:  repoAddress,
+		tx:           tx,
 	}
 }
 
 func (s *SupplierService) AddSupplier(ctx context.Context, supplier model.Supplier, address model.Address) (uuid.UUID, error) {
-	addressID, err := s.repoAddress.CreateAddress(ctx, address)
-	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении адреса: %w", err)
-	}
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var id uuid.UUID
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		addressID, err := s.repoAddress.CreateAddress(ctx, address)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении адреса: %w", err)
+		}
 
-	supplier.AddressID = addressID
+		supplier.AddressID = addressID
 
-	id, err := s.repoSupplier.AddSupplier(ctx, supplier)
+		id, err = s.repoSupplier.AddSupplier(ctx, supplier)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении поставщика: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении поставщика: %w", err)
+		return uuid.Nil, err
 	}
 
 	return id, nil
 }
 
-func (s *SupplierService) UpdateSupplierAddress(ctx context.Context, SupplierID uuid.UUID, address model.Address) error {
-	addressID, err := s.repoSupplier.GetAddressIDBySupplierID(ctx, SupplierID)
+// UpdateSupplier изменяет название и телефон поставщика и возвращает его новое состояние.
+func (s *SupplierService) UpdateSupplier(ctx context.Context, supplier model.Supplier) (model.Supplier, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	updated, err := s.repoSupplier.UpdateSupplier(ctx, supplier)
 	if err != nil {
-		return fmt.Errorf("ошибка при получении адреса поставщика: %w", err)
+		return model.Supplier{}, fmt.Errorf("ошибка при изменении поставщика: %w", err)
 	}
 
-	address.ID = addressID
+	return updated, nil
+}
+
+func (s *SupplierService) GetSupplierAddress(ctx context.Context, supplierID uuid.UUID) (model.Address, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
 
-	err = s.repoAddress.UpdateAddress(ctx, address)
+	addressID, err := s.repoSupplier.GetAddressIDBySupplierID(ctx, supplierID)
 	if err != nil {
-		return fmt.Errorf("ошибка при изменении адреса поставщика: %w", err)
+		return model.Address{}, fmt.Errorf("ошибка при получении адреса поставщика: %w", err)
 	}
 
-	return nil
+	address, err := s.repoAddress.GetAddressByID(ctx, addressID)
+	if err != nil {
+		return model.Address{}, fmt.Errorf("ошибка при получении адреса поставщика: %w", err)
+	}
+
+	return address, nil
 }
 
-func (s *SupplierService) RemoveSupplier(ctx context.Context, SupplierID uuid.UUID) error {
-	addressID, err := s.repoSupplier.GetAddressIDBySupplierID(ctx, SupplierID)
+// UpdateSupplierAddress изменяет адрес поставщика, если его версия совпадает с address.Version.
+func (s *SupplierService) UpdateSupplierAddress(ctx context.Context, SupplierID uuid.UUID, address model.Address) (model.Address, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var updated model.Address
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		addressID, err := s.repoSupplier.GetAddressIDBySupplierID(ctx, SupplierID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении адреса поставщика: %w", err)
+		}
+
+		address.ID = addressID
+
+		updated, err = s.repoAddress.UpdateAddress(ctx, address)
+		if err != nil {
+			return fmt.Errorf("ошибка при изменении адреса поставщика: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("ошибка при получении адреса поставщика: %w", err)
+		return model.Address{}, err
 	}
 
-	err = s.repoSupplier.DeleteSupplier(ctx, SupplierID)
+	return updated, nil
+}
+
+// RemoveSupplier помечает поставщика удалённым, если он не менялся с версии version.
+// Товары поставщика остаются с прежней ссылкой, а адрес стирается вместе с ним при очистке.
+func (s *SupplierService) RemoveSupplier(ctx context.Context, SupplierID uuid.UUID, version int) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	err := s.repoSupplier.DeleteSupplier(ctx, SupplierID, version)
 	if err != nil {
 		return fmt.Errorf("ошибка при удалении поставщика: %w", err)
 	}
 
-	err = s.repoAddress.DeleteAddress(ctx, addressID)
+	return nil
+}
+
+// RestoreSupplier восстанавливает удалённого поставщика.
+func (s *SupplierService) RestoreSupplier(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	supplier, err := s.repoSupplier.RestoreSupplier(ctx, supplierID)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении адреса: %w", err)
+		return model.Supplier{}, fmt.Errorf("ошибка при восстановлении поставщика: %w", err)
 	}
 
-	return nil
+	slog.InfoContext(ctx, "supplier restored", "supplier_id", supplier.ID)
+	return supplier, nil
+}
+
+// supplierSortKey задаёт порядок списка поставщиков: по названию, затем по id.
+var supplierSortKey = sortKey[model.Supplier]{
+	name:  "name",
+	value: func(s model.Supplier) string { return s.Name },
+	id:    func(s model.Supplier) uuid.UUID { return s.ID },
+	valid: anyValue,
 }
 
-func (s *SupplierService) GetSuppliersList(ctx context.Context) ([]model.Supplier, error) {
-	users, err := s.repoSupplier.GetSupplierList(ctx)
+// GetSuppliersList возвращает страницу поставщиков и курсор следующей страницы. Удалённые
+// поставщики попадают в список только с includeDeleted.
+func (s *SupplierService) GetSuppliersList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.Supplier, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if err := supplierSortKey.check(page, false); err != nil {
+		return nil, nil, err
+	}
+
+	suppliers, err := s.repoSupplier.GetSupplierList(ctx, page, includeDeleted)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении списка поставщиков: %w", err)
+		return nil, nil, fmt.Errorf("ошибка при получении списка поставщиков: %w", err)
 	}
 
-	return users, nil
+	suppliers, next := supplierSortKey.next(suppliers, page, false)
+	return suppliers, next, nil
 }
 
 func (s *SupplierService) GetSupplierByID(ctx context.Context, supplierID uuid.UUID) (model.Supplier, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	supplier, err := s.repoSupplier.GetSupplierByID(ctx, supplierID)
 	if err != nil {
 		return model.Supplier{}, fmt.Errorf("ошибка при получении поставщика: %w", err)
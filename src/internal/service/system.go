@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"src/internal/domain"
+	"src/internal/repository"
+	"src/internal/repository/model"
+	"src/internal/tracing"
+	"sync"
+	"time"
+)
+
+// SchemaVersion сообщает применённую и ожидаемую версии схемы базы данных. Реализуется migrator.Migrator.
+type SchemaVersion interface {
+	Version(ctx context.Context) (int64, error)
+	Latest() int64
+}
+
+type SystemService struct {
+	repo         repository.System
+	schema       SchemaVersion
+	checkTimeout time.Duration
+}
+
+func NewSystemService(repo repository.System, schema SchemaVersion, checkTimeout time.Duration) *SystemService {
+	return &SystemService{
+		repo:         repo,
+		schema:       schema,
+		checkTimeout: checkTimeout,
+	}
+}
+
+func (s *SystemService) GetPoolStats() model.PoolStats {
+	return s.repo.PoolStats()
+}
+
+// CheckDependencies параллельно проверяет соединение с Postgres и версию схемы.
+// Каждая проверка ограничена checkTimeout.
+func (s *SystemService) CheckDependencies(ctx context.Context) []model.DependencyCheck {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	checks := []func(ctx context.Context) model.DependencyCheck{s.checkPostgres, s.checkMigrations}
+	results := make([]model.DependencyCheck, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, s.checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			results[i] = check(ctx)
+			results[i].Healthy = results[i].Err == nil
+			results[i].Duration = time.Since(start)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (s *SystemService) checkPostgres(ctx context.Context) model.DependencyCheck {
+	return model.DependencyCheck{
+		Name: model.DependencyPostgres,
+		Err:  s.repo.Ping(ctx),
+	}
+}
+
+func (s *SystemService) checkMigrations(ctx context.Context) model.DependencyCheck {
+	check := model.DependencyCheck{
+		Name:            model.DependencyMigrations,
+		ExpectedVersion: s.schema.Latest(),
+	}
+
+	check.Version, check.Err = s.schema.Version(ctx)
+	if check.Err == nil && check.Version != check.ExpectedVersion {
+		check.Err = domain.New(domain.ErrConflict, "health.schema_mismatch", check.Version, check.ExpectedVersion)
+	}
+
+	return check
+}
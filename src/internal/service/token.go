@@ -0,0 +1,86 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"slices"
+	"src/internal/domain"
+	"src/internal/repository/model"
+	"time"
+)
+
+// accessClaims — содержимое токена доступа.
+type accessClaims struct {
+	Login string `json:"login"`
+	Role  string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// accessTokens подписывает и проверяет токены доступа (JWT) сотрудников и клиентов.
+type accessTokens struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+func newAccessTokens(secret []byte, issuer string, ttl time.Duration) *accessTokens {
+	return &accessTokens{secret: secret, issuer: issuer, ttl: ttl}
+}
+
+func (a *accessTokens) sign(principal model.Principal) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(a.ttl)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		Login: principal.Login,
+		Role:  principal.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.issuer,
+			Subject:   principal.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}).SignedString(a.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("ошибка при подписи токена доступа: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
+
+// parse проверяет подпись и срок действия токена доступа.
+func (a *accessTokens) parse(token string) (model.Principal, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return a.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(a.issuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return model.Principal{}, domain.Wrap(domain.ErrUnauthorized, err, "auth.invalid_token")
+	}
+
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil || (claims.Role != model.RoleClient && !slices.Contains(model.StaffRoles, claims.Role)) {
+		return model.Principal{}, domain.New(domain.ErrUnauthorized, "auth.invalid_token")
+	}
+
+	return model.Principal{ID: id, Login: claims.Login, Role: claims.Role}, nil
+}
+
+// randomToken возвращает случайный непрозрачный токен. В базе хранится только его хеш.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("ошибка при генерации токена: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -4,58 +4,133 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"log/slog"
+	"slices"
+	"src/internal/domain"
 	"src/internal/repository"
 	"src/internal/repository/model"
+	"src/internal/tracing"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 type UserService struct {
 	repoUser    repository.User
 	repoAddress repository.Address
+	tx          repository.Transaction
 }
 
-func NewUserService(repoUser repository.User, repoAddress repository.Address) *UserService {
+func NewUserService(repoUser repository.User, repoAddress repository.Address, tx repository.Transaction) *UserService {
 	return &UserService{
 		repoUser:    repoUser,
 		repoAddress: repoAddress,
+		tx:          tx,
 	}
 }
 
 func (s *UserService) AddUser(ctx context.Context, user model.User, address model.Address) (uuid.UUID, error) {
-	addressID, err := s.repoAddress.CreateAddress(ctx, address)
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var id uuid.UUID
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		addressID, err := s.repoAddress.CreateAddress(ctx, address)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении адреса: %w", err)
+		}
+
+		user.AddressID = addressID
+
+		id, err = s.repoUser.AddUser(ctx, user)
+		if err != nil {
+			return fmt.Errorf("ошибка при добавлении пользователя: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении адреса: %w", err)
+		return uuid.Nil, err
 	}
 
-	user.AddressID = addressID
+	return id, nil
+}
+
+// RemoveUser помечает клиента удалённым, если он не менялся с версии version. Адрес клиента
+// сохраняется, чтобы его можно было восстановить, и стирается вместе с ним при очистке.
+func (s *UserService) RemoveUser(ctx context.Context, userID uuid.UUID, version int) error {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
 
-	id, err := s.repoUser.AddUser(ctx, user)
+	err := s.repoUser.DeleteUser(ctx, userID, version)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("ошибка при добавлении пользователя: %w", err)
+		return fmt.Errorf("ошибка при удалении пользователя: %w", err)
 	}
 
-	return id, nil
+	return nil
+}
+
+// RestoreUser восстанавливает удалённого клиента.
+func (s *UserService) RestoreUser(ctx context.Context, userID uuid.UUID) (model.User, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	user, err := s.repoUser.RestoreUser(ctx, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("ошибка при восстановлении пользователя: %w", err)
+	}
+
+	slog.InfoContext(ctx, "user restored", "user_id", user.ID)
+	return user, nil
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, userID uuid.UUID) (model.User, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	user, err := s.repoUser.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("ошибка при получении пользователя: %w", err)
+	}
+
+	return user, nil
 }
 
-func (s *UserService) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+func (s *UserService) GetUserAddress(ctx context.Context, userID uuid.UUID) (model.Address, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	addressID, err := s.repoUser.GetAddressIDByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("ошибка при получении адреса пользователя: %w", err)
+		return model.Address{}, fmt.Errorf("ошибка при получении адреса пользователя: %w", err)
 	}
 
-	err = s.repoUser.DeleteUser(ctx, userID)
+	address, err := s.repoAddress.GetAddressByID(ctx, addressID)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении пользователя: %w", err)
+		return model.Address{}, fmt.Errorf("ошибка при получении адреса пользователя: %w", err)
 	}
 
-	err = s.repoAddress.DeleteAddress(ctx, addressID)
+	return address, nil
+}
+
+// UpdateUser изменяет личные данные клиента и возвращает его новое состояние.
+func (s *UserService) UpdateUser(ctx context.Context, user model.User) (model.User, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	updated, err := s.repoUser.UpdateUser(ctx, user)
 	if err != nil {
-		return fmt.Errorf("ошибка при удалении адреса: %w", err)
+		return model.User{}, fmt.Errorf("ошибка при изменении пользователя: %w", err)
 	}
 
-	return nil
+	return updated, nil
 }
 
 func (s *UserService) GetUsers(ctx context.Context, name, surname string) ([]model.User, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
 	users, err := s.repoUser.GetUserNameSurname(ctx, name, surname)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при получении пользователя: %w", err)
@@ -64,27 +139,116 @@ func (s *UserService) GetUsers(ctx context.Context, name, surname string) ([]mod
 	return users, nil
 }
 
-func (s *UserService) GetUsersList(ctx context.Context, limit, offset int) ([]model.User, error) {
-	users, err := s.repoUser.GetUserList(ctx, limit, offset)
+// userSortKey задаёт порядок списка клиентов: по дате регистрации, затем по id.
+var userSortKey = sortKey[model.User]{
+	name:  "registration_date",
+	value: func(u model.User) string { return formatCursorTime(u.RegistrationDate) },
+	id:    func(u model.User) uuid.UUID { return u.ID },
+	valid: timeValue,
+}
+
+// GetUsersList возвращает страницу клиентов и курсор следующей страницы. Удалённые клиенты
+// попадают в список только с includeDeleted.
+func (s *UserService) GetUsersList(ctx context.Context, page model.Page, includeDeleted bool) ([]model.User, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	if err := userSortKey.check(page, false); err != nil {
+		return nil, nil, err
+	}
+
+	users, err := s.repoUser.GetUserList(ctx, page, includeDeleted)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при получении списка пользователей: %w", err)
+		return nil, nil, fmt.Errorf("ошибка при получении списка пользователей: %w", err)
 	}
 
-	return users, nil
+	users, next := userSortKey.next(users, page, false)
+	return users, next, nil
 }
 
-func (s *UserService) UpdateUserAddress(ctx context.Context, userID uuid.UUID, address model.Address) error {
-	addressID, err := s.repoUser.GetAddressIDByUserID(ctx, userID)
+// userSearchKey задаёт порядок результатов поиска по запросу: по убыванию релевантности.
+var userSearchKey = sortKey[model.UserMatch]{
+	name:  "rank",
+	value: func(u model.UserMatch) string { return strconv.FormatFloat(u.Rank, 'g', -1, 64) },
+	id:    func(u model.UserMatch) uuid.UUID { return u.ID },
+	valid: numberValue,
+}
+
+// userFilterKey задаёт порядок результатов поиска без запроса — тот же, что у списка клиентов.
+var userFilterKey = sortKey[model.UserMatch]{
+	name:  userSortKey.name,
+	value: func(u model.UserMatch) string { return userSortKey.value(u.User) },
+	id:    func(u model.UserMatch) uuid.UUID { return u.ID },
+	valid: userSortKey.valid,
+}
+
+// SearchUsers ищет клиентов по началу и похожести имени и фамилии и по фильтрам,
+// возвращает страницу результатов и курсор следующей страницы.
+func (s *UserService) SearchUsers(ctx context.Context, filter model.ClientFilter) ([]model.User, *model.Cursor, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if utf8.RuneCountInString(filter.Query) > maxSearchQueryLength {
+		return nil, nil, domain.Invalid("q", "validation.max", maxSearchQueryLength)
+	}
+	if filter.Gender != "" && !slices.Contains(model.Genders, filter.Gender) {
+		return nil, nil, domain.Invalid("gender", "validation.oneof", strings.Join(model.Genders, " "))
+	}
+	if filter.BirthdayFrom != nil && filter.BirthdayTo != nil && filter.BirthdayFrom.After(*filter.BirthdayTo) {
+		return nil, nil, domain.Invalid("birthday_to", "request.date_range_invalid")
+	}
+	if filter.RegisteredFrom != nil && filter.RegisteredTo != nil && filter.RegisteredFrom.After(*filter.RegisteredTo) {
+		return nil, nil, domain.Invalid("registered_to", "request.date_range_invalid")
+	}
+
+	key, desc := userFilterKey, false
+	if filter.Query != "" {
+		key, desc = userSearchKey, true
+	}
+	if err := key.check(filter.Page, desc); err != nil {
+		return nil, nil, err
+	}
+
+	matches, err := s.repoUser.SearchUsers(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("ошибка при получении адреса пользователя: %w", err)
+		return nil, nil, fmt.Errorf("ошибка при поиске пользователей: %w", err)
+	}
+
+	matches, next := key.next(matches, filter.Page, desc)
+
+	users := make([]model.User, len(matches))
+	for i, match := range matches {
+		users[i] = match.User
 	}
 
-	address.ID = addressID
+	return users, next, nil
+}
+
+// UpdateUserAddress изменяет адрес клиента, если его версия совпадает с address.Version.
+func (s *UserService) UpdateUserAddress(ctx context.Context, userID uuid.UUID, address model.Address) (model.Address, error) {
+	ctx, span := tracing.Start(ctx)
+	defer span.End()
+
+	var updated model.Address
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		addressID, err := s.repoUser.GetAddressIDByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("ошибка при получении адреса пользователя: %w", err)
+		}
 
-	err = s.repoAddress.UpdateAddress(ctx, address)
+		address.ID = addressID
+
+		updated, err = s.repoAddress.UpdateAddress(ctx, address)
+		if err != nil {
+			return fmt.Errorf("ошибка при изменении адреса пользователя: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("ошибка при изменении адреса пользователя: %w", err)
+		return model.Address{}, err
 	}
 
-	return nil
+	return updated, nil
 }
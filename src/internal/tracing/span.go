@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"runtime"
+	"strings"
+)
+
+const instrumentation = "src/internal/service"
+
+// Start открывает span с именем вызвавшего метода, например ProductService.CreateProduct.
+// Используется в начале методов сервисов:
+//
+//	ctx, span := tracing.Start(ctx)
+//	defer span.End()
+func Start(ctx context.Context) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, callerName())
+}
+
+func callerName() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"src/internal/logger"
+	"src/internal/service"
+	"time"
+)
+
+// DeletedPurger периодически окончательно удаляет записи, помеченные удалёнными
+// дольше срока хранения.
+type DeletedPurger struct {
+	service  service.Purge
+	interval time.Duration
+}
+
+func NewDeletedPurger(service service.Purge, interval time.Duration) *DeletedPurger {
+	return &DeletedPurger{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run блокируется до отмены ctx.
+func (w *DeletedPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := w.service.PurgeDeleted(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "deleted records purge failed", logger.Err(err))
+				continue
+			}
+			if result.Total() > 0 {
+				slog.InfoContext(ctx, "deleted records purged",
+					"products", result.Products, "suppliers", result.Suppliers, "users", result.Users)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"src/internal/logger"
+	"src/internal/service"
+	"time"
+)
+
+// ReservationSweeper периодически снимает истёкшие резервы товаров.
+type ReservationSweeper struct {
+	service  service.Reservation
+	interval time.Duration
+}
+
+func NewReservationSweeper(service service.Reservation, interval time.Duration) *ReservationSweeper {
+	return &ReservationSweeper{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run блокируется до отмены ctx.
+func (w *ReservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := w.service.ExpireReservations(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "reservation sweeper failed", logger.Err(err))
+				continue
+			}
+			if expired > 0 {
+				slog.InfoContext(ctx, "reservations expired", "count", expired)
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+CREATE TABLE stock_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    delta INT NOT NULL CHECK (delta <> 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('sale', 'restock', 'adjustment', 'return')),
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX stock_movements_product_id_idx ON stock_movements (product_id, created_at DESC);
+
+CREATE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_no_update
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+INSERT INTO stock_movements (product_id, delta, reason, actor)
+SELECT id, available_stock, 'adjustment', 'migration'
+FROM product
+WHERE available_stock > 0;
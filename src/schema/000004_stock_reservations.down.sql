@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS stock_reservations;
+
+ALTER TABLE product
+    DROP CONSTRAINT IF EXISTS product_reserved_within_available,
+    DROP COLUMN IF EXISTS reserved_stock;
@@ -0,0 +1,15 @@
+ALTER TABLE product
+    ADD COLUMN reserved_stock INT NOT NULL DEFAULT 0 CHECK (reserved_stock >= 0),
+    ADD CONSTRAINT product_reserved_within_available CHECK (reserved_stock <= available_stock);
+
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'confirmed', 'released', 'expired')),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX stock_reservations_active_expires_at_idx ON stock_reservations (expires_at) WHERE status = 'active';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS staff_refresh_tokens;
+DROP TABLE IF EXISTS staff_users;
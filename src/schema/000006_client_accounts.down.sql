@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS client_tokens;
+DROP TABLE IF EXISTS client_credentials;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS product_last_update_date_idx;
+DROP INDEX IF EXISTS product_price_idx;
+DROP INDEX IF EXISTS product_supplier_id_idx;
+DROP INDEX IF EXISTS product_category_idx;
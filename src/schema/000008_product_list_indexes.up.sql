@@ -0,0 +1,4 @@
+CREATE INDEX product_category_idx ON product (category);
+CREATE INDEX product_supplier_id_idx ON product (supplier_id);
+CREATE INDEX product_price_idx ON product (price);
+CREATE INDEX product_last_update_date_idx ON product (last_update_date);
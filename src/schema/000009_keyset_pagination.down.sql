@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS product_last_update_date_id_idx;
+DROP INDEX IF EXISTS product_available_stock_id_idx;
+DROP INDEX IF EXISTS product_price_id_idx;
+CREATE INDEX product_price_idx ON product (price);
+CREATE INDEX product_last_update_date_idx ON product (last_update_date);
+
+DROP INDEX IF EXISTS product_name_id_idx;
+DROP INDEX IF EXISTS supplier_name_id_idx;
+DROP INDEX IF EXISTS client_registration_date_id_idx;
+
+ALTER TABLE product ALTER COLUMN last_update_date DROP NOT NULL;
+ALTER TABLE client ALTER COLUMN registration_date DROP NOT NULL;
@@ -0,0 +1,15 @@
+UPDATE client SET registration_date = CURRENT_TIMESTAMP WHERE registration_date IS NULL;
+ALTER TABLE client ALTER COLUMN registration_date SET NOT NULL;
+
+UPDATE product SET last_update_date = CURRENT_TIMESTAMP WHERE last_update_date IS NULL;
+ALTER TABLE product ALTER COLUMN last_update_date SET NOT NULL;
+
+CREATE INDEX client_registration_date_id_idx ON client (registration_date, id);
+CREATE INDEX supplier_name_id_idx ON supplier (name, id);
+CREATE INDEX product_name_id_idx ON product (name, id);
+
+DROP INDEX IF EXISTS product_price_idx;
+DROP INDEX IF EXISTS product_last_update_date_idx;
+CREATE INDEX product_price_id_idx ON product (price, id);
+CREATE INDEX product_available_stock_id_idx ON product (available_stock, id);
+CREATE INDEX product_last_update_date_id_idx ON product (last_update_date, id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS product_category_trgm_idx;
+DROP INDEX IF EXISTS product_name_trgm_idx;
+DROP INDEX IF EXISTS product_search_vector_idx;
+
+ALTER TABLE product DROP COLUMN IF EXISTS search_vector;
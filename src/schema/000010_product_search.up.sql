@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Поисковый вектор пересчитывается самим Postgres при каждом изменении названия или категории.
+-- Текст разбирается и русской, и английской конфигурацией, чтобы запросы на обоих языках
+-- находили товар по словоформам. Совпадения в названии весят больше, чем в категории.
+ALTER TABLE product ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', name), 'A') ||
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('russian', category), 'B') ||
+    setweight(to_tsvector('english', category), 'B')
+) STORED;
+
+CREATE INDEX product_search_vector_idx ON product USING GIN (search_vector);
+CREATE INDEX product_name_trgm_idx ON product USING GIN (name gin_trgm_ops);
+CREATE INDEX product_category_trgm_idx ON product USING GIN (category gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS address_country_lower_idx;
+DROP INDEX IF EXISTS address_city_lower_idx;
+DROP INDEX IF EXISTS client_birthday_idx;
+DROP INDEX IF EXISTS client_surname_trgm_idx;
+DROP INDEX IF EXISTS client_name_trgm_idx;
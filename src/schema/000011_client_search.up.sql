@@ -0,0 +1,7 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX client_name_trgm_idx ON client USING GIN (client_name gin_trgm_ops);
+CREATE INDEX client_surname_trgm_idx ON client USING GIN (client_surname gin_trgm_ops);
+CREATE INDEX client_birthday_idx ON client (birthday);
+CREATE INDEX address_city_lower_idx ON address (lower(city));
+CREATE INDEX address_country_lower_idx ON address (lower(country));
@@ -0,0 +1,4 @@
+ALTER TABLE address DROP COLUMN IF EXISTS version;
+ALTER TABLE supplier DROP COLUMN IF EXISTS version;
+ALTER TABLE client DROP COLUMN IF EXISTS version;
+ALTER TABLE product DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+ALTER TABLE product ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE client ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE supplier ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE address ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,14 @@
+ALTER TABLE product
+    DROP CONSTRAINT product_supplier_id_fkey,
+    ADD CONSTRAINT product_supplier_id_fkey FOREIGN KEY (supplier_id) REFERENCES supplier(id) ON DELETE SET NULL;
+
+DROP INDEX IF EXISTS supplier_phone_number_key;
+ALTER TABLE supplier ADD CONSTRAINT supplier_phone_number_key UNIQUE (phone_number);
+
+DROP INDEX IF EXISTS supplier_deleted_at_idx;
+DROP INDEX IF EXISTS client_deleted_at_idx;
+DROP INDEX IF EXISTS product_deleted_at_idx;
+
+ALTER TABLE supplier DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE client DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE product DROP COLUMN IF EXISTS deleted_at;
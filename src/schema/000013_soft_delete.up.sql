@@ -0,0 +1,14 @@
+ALTER TABLE product ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE client ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE supplier ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX product_deleted_at_idx ON product (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX client_deleted_at_idx ON client (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX supplier_deleted_at_idx ON supplier (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE supplier DROP CONSTRAINT supplier_phone_number_key;
+CREATE UNIQUE INDEX supplier_phone_number_key ON supplier (phone_number) WHERE deleted_at IS NULL;
+
+ALTER TABLE product
+    DROP CONSTRAINT product_supplier_id_fkey,
+    ADD CONSTRAINT product_supplier_id_fkey FOREIGN KEY (supplier_id) REFERENCES supplier(id) ON DELETE RESTRICT;
@@ -0,0 +1,15 @@
+ALTER TABLE supplier_api_keys
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN revoked_at TYPE TIMESTAMP USING revoked_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN last_used_at TYPE TIMESTAMP USING last_used_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE client_tokens
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN used_at TYPE TIMESTAMP USING used_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE staff_refresh_tokens
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN revoked_at TYPE TIMESTAMP USING revoked_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE stock_reservations
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE current_setting('TimeZone');
@@ -0,0 +1,19 @@
+-- Сроки действия сравниваются с текущим моментом и в приложении, и в БД. В TIMESTAMP
+-- без часового пояса pgx теряет смещение, поэтому при разных часовых поясах приложения
+-- и сессии БД резервы и токены истекали раньше или позже срока. Существующие значения
+-- трактуются в часовом поясе сессии, в котором они и записывались.
+ALTER TABLE stock_reservations
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE staff_refresh_tokens
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN revoked_at TYPE TIMESTAMPTZ USING revoked_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE client_tokens
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN used_at TYPE TIMESTAMPTZ USING used_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE supplier_api_keys
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN revoked_at TYPE TIMESTAMPTZ USING revoked_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN last_used_at TYPE TIMESTAMPTZ USING last_used_at AT TIME ZONE current_setting('TimeZone');
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS stock_movements_no_truncate ON stock_movements;
+DROP TRIGGER IF EXISTS stock_movements_no_update ON stock_movements;
+
+CREATE TRIGGER stock_movements_no_update
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_product_id_fkey,
+    ADD CONSTRAINT stock_movements_product_id_fkey FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE;
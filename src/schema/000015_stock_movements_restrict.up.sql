@@ -0,0 +1,15 @@
+-- Журнал движения товара только пополняется: его нельзя ни изменить, ни очистить,
+-- в том числе каскадно при удалении товара.
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_product_id_fkey,
+    ADD CONSTRAINT stock_movements_product_id_fkey FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE RESTRICT;
+
+DROP TRIGGER stock_movements_no_update ON stock_movements;
+
+CREATE TRIGGER stock_movements_no_update
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
+
+CREATE TRIGGER stock_movements_no_truncate
+    BEFORE TRUNCATE ON stock_movements
+    FOR EACH STATEMENT EXECUTE FUNCTION stock_movements_append_only();
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE stock_movements DISABLE TRIGGER stock_movements_no_update;
+DELETE FROM stock_movements WHERE product_id IS NULL;
+ALTER TABLE stock_movements ENABLE TRIGGER stock_movements_no_update;
+
+ALTER TABLE stock_movements
+    DROP CONSTRAINT stock_movements_product_id_fkey,
+    ADD CONSTRAINT stock_movements_product_id_fkey FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE RESTRICT,
+    ALTER COLUMN product_id SET NOT NULL,
+    DROP COLUMN product_name;
@@ -0,0 +1,27 @@
+-- Журнал движения переживает окончательное удаление товара: ссылка на товар обнуляется,
+-- а название товара на момент движения хранится в самой записи.
+ALTER TABLE stock_movements ADD COLUMN product_name VARCHAR(255);
+
+ALTER TABLE stock_movements DISABLE TRIGGER stock_movements_no_update;
+UPDATE stock_movements m SET product_name = p.name FROM product p WHERE p.id = m.product_id;
+ALTER TABLE stock_movements ENABLE TRIGGER stock_movements_no_update;
+
+ALTER TABLE stock_movements
+    ALTER COLUMN product_name SET NOT NULL,
+    ALTER COLUMN product_id DROP NOT NULL,
+    DROP CONSTRAINT stock_movements_product_id_fkey,
+    ADD CONSTRAINT stock_movements_product_id_fkey FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE SET NULL;
+
+-- Единственное допустимое изменение записи журнала — обнуление ссылки на удалённый товар,
+-- которое выполняет внешний ключ.
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.product_id IS NOT NULL AND NEW.product_id IS NULL
+        AND (NEW.id, NEW.delta, NEW.reason, NEW.actor, NEW.created_at, NEW.product_name)
+            IS NOT DISTINCT FROM (OLD.id, OLD.delta, OLD.reason, OLD.actor, OLD.created_at, OLD.product_name) THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
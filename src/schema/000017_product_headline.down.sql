@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS product_headline(TEXT, TEXT);
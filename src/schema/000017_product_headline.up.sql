@@ -0,0 +1,18 @@
+-- product_headline подсвечивает в тексте слова из поискового запроса тегом <mark>.
+-- Текст сначала экранируется, поэтому результат — безопасный HTML: разметку в нём
+-- добавляет только сама подсветка. Используются те же конфигурации, что и в search_vector
+-- и в запросе поиска: сначала русская, а если она ничего не нашла — английская.
+CREATE FUNCTION product_headline(document TEXT, query TEXT) RETURNS TEXT AS $$
+    SELECT CASE WHEN strpos(h.russian, '<mark>') > 0 THEN h.russian ELSE h.english END
+    FROM (
+        SELECT
+            ts_headline('russian', e.escaped, websearch_to_tsquery('russian', query),
+                'HighlightAll=true, StartSel=<mark>, StopSel=</mark>') AS russian,
+            ts_headline('english', e.escaped, websearch_to_tsquery('english', query),
+                'HighlightAll=true, StartSel=<mark>, StopSel=</mark>') AS english
+        FROM (
+            SELECT replace(replace(replace(replace(replace(document,
+                '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;') AS escaped
+        ) e
+    ) h;
+$$ LANGUAGE sql IMMUTABLE;
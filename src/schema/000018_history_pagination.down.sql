@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS supplier_api_keys_supplier_id_created_at_id_idx;
+DROP INDEX IF EXISTS stock_movements_product_id_created_at_id_idx;
+DROP INDEX IF EXISTS orders_client_id_created_at_id_idx;
+CREATE INDEX supplier_api_keys_supplier_id_idx ON supplier_api_keys (supplier_id);
+CREATE INDEX stock_movements_product_id_idx ON stock_movements (product_id, created_at DESC);
+CREATE INDEX orders_client_id_idx ON orders (client_id, created_at DESC);
+
+ALTER TABLE orders ALTER COLUMN created_at DROP NOT NULL;
@@ -0,0 +1,11 @@
+-- Заказы клиента, журнал движения товара и API-ключи поставщика отдаются страницами
+-- от новых к старым по паре (created_at, id). Ключ сортировки не может быть NULL.
+UPDATE orders SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE orders ALTER COLUMN created_at SET NOT NULL;
+
+DROP INDEX IF EXISTS orders_client_id_idx;
+DROP INDEX IF EXISTS stock_movements_product_id_idx;
+DROP INDEX IF EXISTS supplier_api_keys_supplier_id_idx;
+CREATE INDEX orders_client_id_created_at_id_idx ON orders (client_id, created_at, id);
+CREATE INDEX stock_movements_product_id_created_at_id_idx ON stock_movements (product_id, created_at, id);
+CREATE INDEX supplier_api_keys_supplier_id_created_at_id_idx ON supplier_api_keys (supplier_id, created_at, id);
@@ -2,15 +2,20 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"sync"
 	"time"
 )
 
 type Server struct {
+	mu        sync.Mutex
 	httpSever *http.Server
 }
 
+// Run запускает HTTP-сервер и блокируется до его остановки. После вызова Shutdown возвращает nil.
 func (s *Server) Run(port string, handler http.Handler) error {
+	s.mu.Lock()
 	s.httpSever = &http.Server{
 		Addr:           ":" + port,
 		Handler:        handler,
@@ -18,9 +23,24 @@ func (s *Server) Run(port string, handler http.Handler) error {
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 	}
-	return s.httpSever.ListenAndServe()
+	httpServer := s.httpSever
+	s.mu.Unlock()
+
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
+// Shutdown перестаёт принимать новые соединения и ждёт завершения начатых запросов,
+// но не дольше, чем позволяет ctx.
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.httpSever.Shutdown(ctx)
+	s.mu.Lock()
+	httpServer := s.httpSever
+	s.mu.Unlock()
+
+	if httpServer == nil {
+		return nil
+	}
+	return httpServer.Shutdown(ctx)
 }